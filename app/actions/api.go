@@ -1,17 +1,187 @@
 // app/actions/api.go
 package actions
 
+import (
+	"bubbletea-app/app/config"
+	"context"
+	"io"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
 // DataItem represents a data structure from API
 type DataItem struct {
-	Title       string
-	Description string
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// Status is one of Statuses, empty counts as StatusTodo
+	Status  string    `json:"status,omitempty"`
+	Created time.Time `json:"created,omitzero"`
+}
+
+// Equal reports whether d and other hold the same item. Created is compared
+// as an instant, so the same time in another location or without a monotonic
+// reading, as decoding JSON gives, counts as equal
+func (d DataItem) Equal(other DataItem) bool {
+	return d.ID == other.ID && d.Title == other.Title && d.Description == other.Description &&
+		d.Status == other.Status && d.Created.Equal(other.Created)
 }
 
-// FetchData simulates an API call to get data
-func FetchData() []DataItem {
-	// In a real app, this would call an API or database
+// Item statuses, in the order they usually go through
+const (
+	StatusTodo  = "todo"
+	StatusDoing = "doing"
+	StatusDone  = "done"
+)
+
+// Statuses lists the item statuses in order
+var Statuses = []string{StatusTodo, StatusDoing, StatusDone}
+
+// ItemStatus returns the status of item, StatusTodo if it has none
+func (d DataItem) ItemStatus() string {
+	if d.Status == "" {
+		return StatusTodo
+	}
+	return d.Status
+}
+
+// NextStatus returns the status after status, wrapping around to the first
+func NextStatus(status string) string {
+	for i, s := range Statuses {
+		if s == status {
+			return Statuses[(i+1)%len(Statuses)]
+		}
+	}
+	return Statuses[0]
+}
+
+// PageSize is how many items a page fetched by LoadPageCmd holds
+const PageSize = 50
+
+// DataLoadedMsg carries a page of items returned by a fetch. Offset is the
+// position of the first item among all items, 0 for a fresh load, and Total
+// counts all items in the backend
+type DataLoadedMsg struct {
+	Items  []DataItem
+	Offset int
+	Total  int
+	// Cached is when the items were fetched, if they come from the cache
+	Cached time.Time
+	// Offline is set when the backend could not be reached and the items
+	// are the ones cached last time
+	Offline bool
+}
+
+// DataErrorMsg reports a failed fetch of the page at Offset. Err is
+// ErrNotConfigured, ErrUnauthorized, an *APIError or a network error
+type DataErrorMsg struct {
+	Err    error
+	Offset int
+}
+
+// SampleData is shown by the mock backend and while no API host is
+// configured
+func SampleData() []DataItem {
+	created := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
 	return []DataItem{
-		{Title: "API Item 1", Description: "Description from API call"},
-		{Title: "API Item 2", Description: "Another description from API"},
+		{ID: "1", Title: "Task 1", Description: "Description for task 1", Status: StatusDone, Created: created},
+		{ID: "2", Title: "Task 2", Description: "Description for task 2", Status: StatusDoing, Created: created.Add(time.Hour)},
+		{ID: "3", Title: "Task 3", Description: "Description for task 3", Status: StatusTodo, Created: created.AddDate(0, 0, 1)},
+	}
+}
+
+// SourceLabel describes where FetchData gets its items from
+func SourceLabel(settings config.Settings) string {
+	switch backend := settings.DataBackend(); backend {
+	case config.BackendREST:
+		if url := BaseURL(settings); url != "" {
+			return url
+		}
+		return "sample data"
+	case config.BackendMock:
+		return "mock data"
+	default:
+		return backend + " " + settings.DataFile()
+	}
+}
+
+// FetchData gets all items from the backend selected in settings
+func FetchData(settings config.Settings) ([]DataItem, error) {
+	source, err := NewDataSource(settings)
+	if err != nil {
+		return nil, err
+	}
+	if closer, ok := source.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	list, err := source.List(context.Background(), Page{})
+	return list.Items, err
+}
+
+// LoadItemsCmd fetches the first page of items from the store's backend.
+// Canceling ctx stops the fetch, which then reports context.Canceled
+func LoadItemsCmd(ctx context.Context, store *Store) tea.Cmd {
+	return LoadPageCmd(ctx, store, 0)
+}
+
+// RefreshItemsCmd fetches the first page again, even when the cached one is
+// still fresh
+func RefreshItemsCmd(ctx context.Context, store *Store) tea.Cmd {
+	return func() tea.Msg {
+		return fetchPage(ctx, store, Page{Limit: PageSize})
+	}
+}
+
+// ReloadItemsCmd fetches the first count items again in one go, rounded up
+// to whole pages, so a refresh keeps what was loaded page by page
+func ReloadItemsCmd(ctx context.Context, store *Store, count int) tea.Cmd {
+	limit := max((count+PageSize-1)/PageSize, 1) * PageSize
+	return func() tea.Msg {
+		return fetchPage(ctx, store, Page{Limit: limit})
+	}
+}
+
+// LoadPageCmd lists up to PageSize items from offset on in the background
+// and reports them as a DataLoadedMsg or DataErrorMsg. A fresh cached page
+// is used as is, a stale one shows up right away and again once the backend
+// answered
+func LoadPageCmd(ctx context.Context, store *Store, offset int) tea.Cmd {
+	page := Page{Offset: offset, Limit: PageSize}
+	return func() tea.Msg {
+		entry, ok := readCache(store.Settings(), page)
+		switch {
+		case !ok:
+			return fetchPage(ctx, store, page)
+		case entry.fresh():
+			return entry.loadedMsg(false)
+		}
+		return tea.Sequence(
+			func() tea.Msg { return entry.loadedMsg(false) },
+			func() tea.Msg { return fetchPage(ctx, store, page) },
+		)()
+	}
+}
+
+// fetchPage lists page from the store's backend and caches it. When the
+// backend can't be reached, the cached page is returned instead
+func fetchPage(ctx context.Context, store *Store, page Page) tea.Msg {
+	settings := store.Settings()
+	source, err := store.Source()
+	if err == nil {
+		var list ItemList
+		list, err = source.List(ctx, page)
+		if err == nil {
+			writeCache(settings, page, list)
+			return DataLoadedMsg{Items: list.Items, Offset: page.Offset, Total: list.Total}
+		}
+	}
+
+	if Unreachable(err) {
+		if entry, ok := readCache(settings, page); ok {
+			return entry.loadedMsg(true)
+		}
 	}
+	return DataErrorMsg{Err: err, Offset: page.Offset}
 }
@@ -0,0 +1,42 @@
+// app/actions/api_test.go
+package actions
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDataItemEqual(t *testing.T) {
+	created := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	item := DataItem{ID: "1", Title: "Write the report", Status: StatusDoing, Created: created}
+
+	// The same instant decoded from JSON, in another location and with a
+	// monotonic reading
+	var decoded DataItem
+	data, _ := json.Marshal(item)
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	inZone := item
+	inZone.Created = created.In(time.FixedZone("CET", 3600))
+	withClock := item
+	withClock.Created = time.Now()
+	withClock.Created = withClock.Created.Add(created.Sub(withClock.Created))
+
+	for name, other := range map[string]DataItem{"decoded": decoded, "other location": inZone, "monotonic": withClock} {
+		if !item.Equal(other) {
+			t.Errorf("%s: %+v doesn't equal %+v", name, other, item)
+		}
+	}
+
+	changed := item
+	changed.Status = StatusDone
+	later := item
+	later.Created = created.Add(time.Second)
+	for name, other := range map[string]DataItem{"status": changed, "created": later} {
+		if item.Equal(other) {
+			t.Errorf("%s: %+v equals %+v", name, other, item)
+		}
+	}
+}
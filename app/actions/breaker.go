@@ -0,0 +1,132 @@
+// app/actions/breaker.go
+package actions
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending the request while the circuit
+// breaker of a failing host is open
+var ErrCircuitOpen = errors.New("API paused after repeated failures")
+
+// CircuitState tells whether requests get through to a host
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through
+	CircuitClosed CircuitState = iota
+	// CircuitOpen turns requests away until the cooldown is over
+	CircuitOpen
+	// CircuitHalfOpen lets one request through to see whether the host is
+	// back
+	CircuitHalfOpen
+)
+
+// Defaults of the circuit breakers used by the API client
+const (
+	DefaultFailureThreshold = 5
+	DefaultCooldown         = 30 * time.Second
+)
+
+// CircuitBreaker stops sending requests to a host after Threshold failures
+// in a row. After Cooldown one request may try again, which closes the
+// circuit if it gets through and opens it again if it doesn't
+type CircuitBreaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	open     bool
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker creates a closed circuit breaker
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{Threshold: threshold, Cooldown: cooldown}
+}
+
+// Allow returns ErrCircuitOpen when a request must not be sent now
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state() {
+	case CircuitOpen:
+		return ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Record counts the outcome of a request let through by Allow. Only errors
+// that say the host is in trouble count as failures
+func (b *CircuitBreaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if err == nil || !retryable(err) {
+		b.failures = 0
+		b.open = false
+		return
+	}
+	b.failures++
+	if b.open || b.failures >= b.Threshold {
+		b.open = true
+		b.openedAt = time.Now()
+	}
+}
+
+// Release gives back a request let through by Allow that ended before it
+// told anything about the host, like one canceled by the user. A canceled
+// probe makes way for the next one
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// State returns the state of the circuit and, while it is open, when it
+// lets a request through again
+func (b *CircuitBreaker) State() (CircuitState, time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state(), b.openedAt.Add(b.Cooldown)
+}
+
+func (b *CircuitBreaker) state() CircuitState {
+	switch {
+	case !b.open:
+		return CircuitClosed
+	case time.Since(b.openedAt) < b.Cooldown:
+		return CircuitOpen
+	}
+	return CircuitHalfOpen
+}
+
+var (
+	breakersMu sync.Mutex
+	breakers   = map[string]*CircuitBreaker{}
+)
+
+// hostBreaker returns the circuit breaker shared by all clients of baseURL,
+// so switching settings back and forth doesn't reset it
+func hostBreaker(baseURL string) *CircuitBreaker {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+
+	b, ok := breakers[baseURL]
+	if !ok {
+		b = NewCircuitBreaker(DefaultFailureThreshold, DefaultCooldown)
+		breakers[baseURL] = b
+	}
+	return b
+}
@@ -0,0 +1,140 @@
+// app/actions/cache.go
+package actions
+
+import (
+	"bubbletea-app/app/config"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CacheTTL is how long fetched items count as fresh. Older ones are still
+// shown while the backend is asked again, and whenever it can't be reached
+const CacheTTL = 5 * time.Minute
+
+// cacheEntry is a page of items as a backend returned it
+type cacheEntry struct {
+	Source  string    `json:"source"`
+	Page    Page      `json:"page"`
+	Saved   time.Time `json:"saved"`
+	Expires time.Time `json:"expires"`
+	List    ItemList  `json:"list"`
+}
+
+// CacheDir returns the folder fetched items are cached in
+func CacheDir() string {
+	return filepath.Join(config.GetConfigPath(), "cache")
+}
+
+// ClearCache removes every cached page
+func ClearCache() error {
+	return os.RemoveAll(CacheDir())
+}
+
+// cacheable reports whether pages fetched with settings are cached. Only the
+// API is, the local backends are always at hand
+func cacheable(settings config.Settings) bool {
+	return settings.DataBackend() == config.BackendREST && BaseURL(settings) != ""
+}
+
+// cachePrefix names the cache files of the backend in settings. The API key
+// is part of it, as other keys may see other items, so it is hashed
+func cachePrefix(settings config.Settings) string {
+	sum := sha256.Sum256([]byte(BaseURL(settings) + "\n" + settings.APIKey))
+	return hex.EncodeToString(sum[:8])
+}
+
+func cacheFile(settings config.Settings, page Page) string {
+	name := fmt.Sprintf("%s-%d-%d.json", cachePrefix(settings), page.Offset, page.Limit)
+	return filepath.Join(CacheDir(), name)
+}
+
+// readCache returns the cached page of the backend in settings
+func readCache(settings config.Settings, page Page) (cacheEntry, bool) {
+	if !cacheable(settings) {
+		return cacheEntry{}, false
+	}
+	data, err := os.ReadFile(cacheFile(settings, page))
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// writeCache stores a page fetched from the backend in settings
+func writeCache(settings config.Settings, page Page, list ItemList) error {
+	if !cacheable(settings) {
+		return nil
+	}
+	now := time.Now()
+	return writeCacheEntry(cacheFile(settings, page), cacheEntry{
+		Source:  BaseURL(settings),
+		Page:    page,
+		Saved:   now,
+		Expires: now.Add(CacheTTL),
+		List:    list,
+	})
+}
+
+// expireCache marks the cached pages of the backend in settings as stale,
+// so the next load asks the backend. They stay around for offline use
+func expireCache(settings config.Settings) {
+	if !cacheable(settings) {
+		return
+	}
+	paths, _ := filepath.Glob(filepath.Join(CacheDir(), cachePrefix(settings)+"-*.json"))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var entry cacheEntry
+		if json.Unmarshal(data, &entry) != nil || entry.Expires.IsZero() {
+			continue
+		}
+		entry.Expires = time.Time{}
+		writeCacheEntry(path, entry)
+	}
+}
+
+// writeCacheEntry writes entry through a temporary file, so a crash can't
+// leave half a page behind. Items may be private, so only the user can read
+// them
+func writeCacheEntry(path string, entry cacheEntry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// fresh reports whether the entry can be shown without asking the backend
+func (e cacheEntry) fresh() bool {
+	return time.Now().Before(e.Expires)
+}
+
+// loadedMsg returns the cached page as if it was just fetched
+func (e cacheEntry) loadedMsg(offline bool) DataLoadedMsg {
+	return DataLoadedMsg{
+		Items:   e.List.Items,
+		Offset:  e.Page.Offset,
+		Total:   e.List.Total,
+		Cached:  e.Saved,
+		Offline: offline,
+	}
+}
@@ -0,0 +1,199 @@
+// app/actions/cache_test.go
+package actions_test
+
+import (
+	"bubbletea-app/app/actions"
+	"bubbletea-app/app/config"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newCachedStore returns a store for the API in settings that retries
+// quickly
+func newCachedStore(t *testing.T, settings config.Settings) *actions.Store {
+	t.Helper()
+	store := actions.NewStore(settings)
+	t.Cleanup(func() { store.Close() })
+	source, err := store.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := source.(*actions.Client)
+	client.Retry, client.Breaker = quickRetry, nil
+	return store
+}
+
+// useConfigDir points the config dir, and with it the cache, at a new
+// folder
+func useConfigDir(t *testing.T) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", home)
+}
+
+// load runs cmd and returns the messages it sends, in order. A stale page
+// sends two, the cached one and the fetched one
+func load(cmd tea.Cmd) []tea.Msg {
+	msg := cmd()
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Slice || v.Type().Elem() != reflect.TypeOf(tea.Cmd(nil)) {
+		return []tea.Msg{msg}
+	}
+	var msgs []tea.Msg
+	for i := range v.Len() {
+		msgs = append(msgs, load(v.Index(i).Interface().(tea.Cmd))...)
+	}
+	return msgs
+}
+
+// setExpires moves the expiry of every cached page to when
+func setExpires(t *testing.T, when time.Time) {
+	t.Helper()
+	paths, _ := filepath.Glob(filepath.Join(actions.CacheDir(), "*.json"))
+	if len(paths) == 0 {
+		t.Fatal("nothing cached")
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var entry map[string]any
+		if err := json.Unmarshal(data, &entry); err != nil {
+			t.Fatal(err)
+		}
+		entry["expires"] = when
+		if data, err = json.Marshal(entry); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		expires time.Duration
+		// want are the messages a load sends, cached or not
+		want []bool
+	}{
+		{"fresh", time.Second, []bool{true}},
+		{"stale", -time.Second, []bool{true, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useConfigDir(t)
+			server := newServer(t, "")
+			store := newCachedStore(t, server.Settings())
+			first := load(actions.LoadItemsCmd(context.Background(), store))
+			if msg := first[0].(actions.DataLoadedMsg); !msg.Cached.IsZero() {
+				t.Fatal("the first load came from the cache")
+			}
+
+			setExpires(t, time.Now().Add(tt.expires))
+			requests := server.Requests()
+			msgs := load(actions.LoadItemsCmd(context.Background(), store))
+			var cached []bool
+			for _, msg := range msgs {
+				msg := msg.(actions.DataLoadedMsg)
+				cached = append(cached, !msg.Cached.IsZero())
+				if got := ids(msg.Items); !slices.Equal(got, []string{"1", "2", "3"}) || msg.Offline {
+					t.Errorf("loaded %v, offline %v", got, msg.Offline)
+				}
+			}
+			if !slices.Equal(cached, tt.want) {
+				t.Errorf("got messages cached %v, want %v", cached, tt.want)
+			}
+			if asked := server.Requests() - requests; asked != len(tt.want)-1 {
+				t.Errorf("asked the backend %d times", asked)
+			}
+		})
+	}
+}
+
+func TestCacheExpiresAfterTTL(t *testing.T) {
+	useConfigDir(t)
+	server := newServer(t, "")
+	store := newCachedStore(t, server.Settings())
+	load(actions.LoadItemsCmd(context.Background(), store))
+
+	paths, _ := filepath.Glob(filepath.Join(actions.CacheDir(), "*.json"))
+	if len(paths) != 1 {
+		t.Fatalf("cached %d pages, want 1", len(paths))
+	}
+	data, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entry struct{ Saved, Expires time.Time }
+	if err := json.Unmarshal(data, &entry); err != nil {
+		t.Fatal(err)
+	}
+	if ttl := entry.Expires.Sub(entry.Saved); ttl != actions.CacheTTL {
+		t.Errorf("the page expires after %s, want %s", ttl, actions.CacheTTL)
+	}
+}
+
+func TestCacheOffline(t *testing.T) {
+	tests := []struct {
+		name string
+		// change changes what is loaded from the closed server
+		change     func(t *testing.T, settings *config.Settings, offset *int)
+		wantCached bool
+	}{
+		{"same page", func(*testing.T, *config.Settings, *int) {}, true},
+		{"other page", func(_ *testing.T, _ *config.Settings, offset *int) { *offset = actions.PageSize }, false},
+		{"other key", func(_ *testing.T, settings *config.Settings, _ *int) { settings.APIKey = "other" }, false},
+		{"other host", func(_ *testing.T, settings *config.Settings, _ *int) { settings.Host = "localhost" }, false},
+		{"cleared", func(t *testing.T, _ *config.Settings, _ *int) {
+			if err := actions.ClearCache(); err != nil {
+				t.Fatal(err)
+			}
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useConfigDir(t)
+			server := newServer(t, "")
+			settings := server.Settings()
+			load(actions.LoadItemsCmd(context.Background(), newCachedStore(t, settings)))
+			server.Close()
+
+			offset := 0
+			tt.change(t, &settings, &offset)
+			store := newCachedStore(t, settings)
+			// A refresh skips the fresh page in the cache
+			cmd := actions.RefreshItemsCmd(context.Background(), store)
+			if offset > 0 {
+				cmd = actions.LoadPageCmd(context.Background(), store, offset)
+			}
+			msg := cmd()
+			switch msg := msg.(type) {
+			case actions.DataLoadedMsg:
+				if !tt.wantCached {
+					t.Fatalf("loaded %v from the cache", ids(msg.Items))
+				}
+				if !msg.Offline || msg.Cached.IsZero() || !slices.Equal(ids(msg.Items), []string{"1", "2", "3"}) {
+					t.Errorf("got %+v, want the cached items offline", msg)
+				}
+			case actions.DataErrorMsg:
+				if tt.wantCached {
+					t.Fatalf("got %v, want the cached items", msg.Err)
+				}
+			default:
+				t.Fatalf("got %#v", msg)
+			}
+		})
+	}
+}
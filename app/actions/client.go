@@ -0,0 +1,299 @@
+// app/actions/client.go
+package actions
+
+import (
+	"bubbletea-app/app/config"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTimeout bounds every request made by the client
+const DefaultTimeout = 10 * time.Second
+
+// ErrNotConfigured is returned when no host is set in Settings
+var ErrNotConfigured = errors.New("no API host configured")
+
+// ErrUnauthorized is returned when the server rejects the API key
+var ErrUnauthorized = errors.New("API key was rejected")
+
+// APIError is returned when the server answers with an unexpected status
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("API returned %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("API returned %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// Unreachable reports whether err means the API could not be reached, as
+// opposed to the API answering with an error
+func Unreachable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, ErrCircuitOpen) {
+		return true
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		// Proxies answer like this for servers that are down
+		switch apiErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// Client talks to the data API configured on the Settings page. It is the
+// REST DataSource. Idempotent requests that fail are retried following
+// Retry, and Breaker stops all requests while the host keeps failing
+type Client struct {
+	BaseURL string
+	APIKey  string
+	// Timeout bounds every attempt of a request
+	Timeout time.Duration
+	Retry   RetryPolicy
+	Breaker *CircuitBreaker
+	HTTP    *http.Client
+
+	// retrying is the number of the retry being waited for, 0 when there is
+	// none
+	retrying atomic.Int32
+}
+
+// Health tells how requests to a backend are going, for showing it in the
+// status line
+type Health struct {
+	// Retry is the retry being waited for, 0 when none is, out of
+	// MaxRetries
+	Retry, MaxRetries int
+	Circuit           CircuitState
+	// Reopens is when an open circuit lets a request through again
+	Reopens time.Time
+}
+
+// NewClient creates a client for the host, port and API key in settings
+func NewClient(settings config.Settings) *Client {
+	baseURL := BaseURL(settings)
+	return &Client{
+		BaseURL: baseURL,
+		APIKey:  settings.APIKey,
+		Timeout: DefaultTimeout,
+		Retry:   DefaultRetryPolicy,
+		Breaker: hostBreaker(baseURL),
+		HTTP:    http.DefaultClient,
+	}
+}
+
+// BaseURL builds the server URL from the settings. The host may already
+// include a scheme; plain hosts are reached over http
+func BaseURL(settings config.Settings) string {
+	host := strings.TrimRight(strings.TrimSpace(settings.Host), "/")
+	if host == "" {
+		return ""
+	}
+	if !strings.Contains(host, "://") {
+		host = "http://" + host
+	}
+	if settings.Port != "" {
+		scheme, rest, _ := strings.Cut(host, "://")
+		hostname, path, _ := strings.Cut(rest, "/")
+		host = scheme + "://" + net.JoinHostPort(hostname, settings.Port)
+		if path != "" {
+			host += "/" + path
+		}
+	}
+	return host
+}
+
+// Configured reports whether the client has a server to talk to
+func (c *Client) Configured() bool {
+	return c.BaseURL != ""
+}
+
+// Health returns the retry in progress and the state of the circuit
+func (c *Client) Health() Health {
+	health := Health{
+		Retry:      int(c.retrying.Load()),
+		MaxRetries: c.Retry.MaxAttempts - 1,
+	}
+	if c.Breaker != nil {
+		health.Circuit, health.Reopens = c.Breaker.State()
+	}
+	return health
+}
+
+// List gets a page of items. The server reports the total in the
+// X-Total-Count header. Without it a full page may be followed by more, so
+// the total is one past it, and a short page is the last
+func (c *Client) List(ctx context.Context, page Page) (ItemList, error) {
+	query := url.Values{}
+	if page.Offset > 0 {
+		query.Set("offset", strconv.Itoa(page.Offset))
+	}
+	if page.Limit > 0 {
+		query.Set("limit", strconv.Itoa(page.Limit))
+	}
+	path := "/items"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var list ItemList
+	header, err := c.do(ctx, http.MethodGet, path, nil, &list.Items)
+	if err != nil {
+		return ItemList{}, err
+	}
+	list.Total = page.Offset + len(list.Items)
+	if page.Limit > 0 && len(list.Items) == page.Limit {
+		list.Total++
+	}
+	if total, err := strconv.Atoi(header.Get("X-Total-Count")); err == nil {
+		list.Total = total
+	}
+	return list, nil
+}
+
+func (c *Client) Get(ctx context.Context, id string) (DataItem, error) {
+	var item DataItem
+	_, err := c.do(ctx, http.MethodGet, itemPath(id), nil, &item)
+	return item, err
+}
+
+func (c *Client) Create(ctx context.Context, item DataItem) (DataItem, error) {
+	var created DataItem
+	_, err := c.do(ctx, http.MethodPost, "/items", item, &created)
+	return created, err
+}
+
+func (c *Client) Update(ctx context.Context, item DataItem) (DataItem, error) {
+	var updated DataItem
+	_, err := c.do(ctx, http.MethodPut, itemPath(item.ID), item, &updated)
+	return updated, err
+}
+
+func (c *Client) Delete(ctx context.Context, id string) error {
+	_, err := c.do(ctx, http.MethodDelete, itemPath(id), nil, nil)
+	return err
+}
+
+func itemPath(id string) string {
+	return "/items/" + url.PathEscape(id)
+}
+
+// do sends a request with the API key, encoding in as JSON, and decodes the
+// JSON answer into out. It returns the response headers. Failed attempts
+// are retried as far as the retry policy for method allows
+func (c *Client) do(ctx context.Context, method, path string, in, out any) (http.Header, error) {
+	if !c.Configured() {
+		return nil, ErrNotConfigured
+	}
+
+	var data []byte
+	if in != nil {
+		var err error
+		if data, err = json.Marshal(in); err != nil {
+			return nil, err
+		}
+	}
+
+	policy := c.Retry
+	if method == http.MethodPost {
+		policy = NoRetry
+	}
+	defer c.retrying.Store(0)
+
+	for attempt := 1; ; attempt++ {
+		header, err := c.attempt(ctx, method, path, data, out)
+		if err == nil || attempt >= policy.MaxAttempts || !retryable(err) {
+			return header, err
+		}
+		c.retrying.Store(int32(attempt))
+		if err := sleep(ctx, policy.Backoff(attempt)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempt sends a request once, unless the circuit breaker is open
+func (c *Client) attempt(ctx context.Context, method, path string, data []byte, out any) (http.Header, error) {
+	if c.Breaker != nil {
+		if err := c.Breaker.Allow(); err != nil {
+			return nil, err
+		}
+	}
+	header, err := c.send(ctx, method, path, data, out)
+	if c.Breaker != nil {
+		if errors.Is(err, context.Canceled) {
+			c.Breaker.Release()
+		} else {
+			c.Breaker.Record(err)
+		}
+	}
+	return header, err
+}
+
+// send makes a single request with data as the JSON body
+func (c *Client) send(ctx context.Context, method, path string, data []byte, out any) (http.Header, error) {
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, ErrUnauthorized
+	case resp.StatusCode == http.StatusNotFound && strings.HasPrefix(path, "/items/"):
+		return nil, ErrNotFound
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(data))}
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return resp.Header, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return resp.Header, nil
+}
@@ -0,0 +1,213 @@
+// app/actions/client_test.go
+package actions_test
+
+import (
+	"bubbletea-app/app/actions"
+	"bubbletea-app/app/actions/standin"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testItems are the items the stand-in starts with
+var testItems = []actions.DataItem{
+	{ID: "1", Title: "Write the report", Description: "Numbers from Q3", Status: actions.StatusDoing, Created: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)},
+	{ID: "2", Title: "Book the venue", Status: actions.StatusTodo, Created: time.Date(2025, 1, 7, 9, 0, 0, 0, time.UTC)},
+	{ID: "3", Title: "Send invites", Status: actions.StatusDone, Created: time.Date(2025, 1, 8, 9, 0, 0, 0, time.UTC)},
+}
+
+// newServer starts a stand-in holding testItems that wants apiKey
+func newServer(t *testing.T, apiKey string) *standin.Server {
+	t.Helper()
+	server := standin.New(apiKey, testItems...)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newClient creates a client for server that sends every request once and
+// leaves the shared circuit breaker out of it
+func newClient(server *standin.Server) *actions.Client {
+	client := actions.NewClient(server.Settings())
+	client.Retry = actions.NoRetry
+	client.Breaker = nil
+	return client
+}
+
+func TestClientCRUD(t *testing.T) {
+	client := newClient(newServer(t, "secret"))
+	ctx := context.Background()
+
+	list, err := client.List(ctx, actions.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Total != len(testItems) || len(list.Items) != len(testItems) {
+		t.Fatalf("listed %d of %d items, want all %d", len(list.Items), list.Total, len(testItems))
+	}
+	for i, item := range list.Items {
+		if !item.Equal(testItems[i]) {
+			t.Errorf("item %d decoded as %+v, want %+v", i, item, testItems[i])
+		}
+	}
+
+	created, err := client.Create(ctx, actions.DataItem{Title: "Order flowers"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.ID == "" || created.Title != "Order flowers" {
+		t.Fatalf("created %+v", created)
+	}
+
+	created.Status = actions.StatusDone
+	if _, err := client.Update(ctx, created); err != nil {
+		t.Fatal(err)
+	}
+	got, err := client.Get(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(created) {
+		t.Errorf("got %+v after the update, want %+v", got, created)
+	}
+
+	if err := client.Delete(ctx, created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Get(ctx, created.ID); !errors.Is(err, actions.ErrNotFound) {
+		t.Errorf("getting a deleted item: %v, want ErrNotFound", err)
+	}
+}
+
+func TestClientPage(t *testing.T) {
+	client := newClient(newServer(t, ""))
+
+	list, err := client.List(context.Background(), actions.Page{Offset: 1, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 || list.Items[0].ID != "2" || list.Total != len(testItems) {
+		t.Errorf("got %+v, want item 2 of %d", list, len(testItems))
+	}
+}
+
+func TestClientPagesWithoutTotal(t *testing.T) {
+	server := newServer(t, "")
+	server.HideTotal(true)
+	client := newClient(server)
+
+	// A full page may be followed by more, a short one is the last
+	tests := []struct {
+		page  actions.Page
+		items int
+		total int
+	}{
+		{actions.Page{Limit: 2}, 2, 3},
+		{actions.Page{Offset: 2, Limit: 2}, 1, 3},
+		{actions.Page{Limit: 3}, 3, 4},
+		{actions.Page{Offset: 3, Limit: 3}, 0, 3},
+		{actions.Page{}, 3, 3},
+	}
+	for _, tt := range tests {
+		list, err := client.List(context.Background(), tt.page)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list.Items) != tt.items || list.Total != tt.total {
+			t.Errorf("page %+v: got %d items of %d, want %d of %d", tt.page, len(list.Items), list.Total, tt.items, tt.total)
+		}
+	}
+}
+
+func TestClientAPIKey(t *testing.T) {
+	server := newServer(t, "secret")
+
+	settings := server.Settings()
+	settings.APIKey = "wrong"
+	client := actions.NewClient(settings)
+	client.Retry = actions.NoRetry
+	client.Breaker = nil
+	if _, err := client.List(context.Background(), actions.Page{}); !errors.Is(err, actions.ErrUnauthorized) {
+		t.Errorf("wrong key: %v, want ErrUnauthorized", err)
+	}
+
+	settings.APIKey = ""
+	client = actions.NewClient(settings)
+	client.Breaker = nil
+	if _, err := client.List(context.Background(), actions.Page{}); !errors.Is(err, actions.ErrUnauthorized) {
+		t.Errorf("no key: %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestClientStatusErrors(t *testing.T) {
+	tests := []struct {
+		status      int
+		unreachable bool
+	}{
+		{http.StatusBadRequest, false},
+		{http.StatusTeapot, false},
+		{http.StatusInternalServerError, false},
+		{http.StatusBadGateway, true},
+		{http.StatusServiceUnavailable, true},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server := newServer(t, "")
+			client := newClient(server)
+			server.FailNext(1, tt.status)
+
+			_, err := client.List(context.Background(), actions.Page{})
+			var apiErr *actions.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("got %v, want an APIError", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.Body != http.StatusText(tt.status) {
+				t.Errorf("got status %d with %q", apiErr.StatusCode, apiErr.Body)
+			}
+			if got := actions.Unreachable(err); got != tt.unreachable {
+				t.Errorf("Unreachable = %t, want %t", got, tt.unreachable)
+			}
+		})
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	server := newServer(t, "")
+	server.SetDelay(time.Second)
+	client := newClient(server)
+	client.Timeout = 20 * time.Millisecond
+
+	start := time.Now()
+	_, err := client.List(context.Background(), actions.Page{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want a timeout", err)
+	}
+	if !actions.Unreachable(err) {
+		t.Error("a timeout doesn't count as unreachable")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("the request took %s", elapsed)
+	}
+}
+
+func TestClientDecodeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &actions.Client{BaseURL: server.URL, Timeout: time.Second, Retry: actions.NoRetry, HTTP: server.Client()}
+	if _, err := client.Get(context.Background(), "1"); err == nil {
+		t.Error("a broken answer decoded without an error")
+	}
+}
+
+func TestClientNotConfigured(t *testing.T) {
+	client := &actions.Client{Retry: actions.NoRetry}
+	if _, err := client.List(context.Background(), actions.Page{}); !errors.Is(err, actions.ErrNotConfigured) {
+		t.Errorf("got %v, want ErrNotConfigured", err)
+	}
+}
@@ -0,0 +1,81 @@
+// app/actions/clipboard.go
+package actions
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CopiedMsg reports how copying What, like "the title of 'Task 2'", went
+// for the page with id Origin. ViaTerminal means the terminal was asked to
+// copy with OSC52, which it doesn't confirm
+type CopiedMsg struct {
+	Origin      string
+	What        string
+	ViaTerminal bool
+	Err         error
+}
+
+// Terminal is the output the program draws to. Writes are serialized, so an
+// OSC52 sequence for the clipboard goes between two frames instead of into
+// one. It is still the terminal's file, so the program sees a terminal
+type Terminal struct {
+	*os.File
+	mu sync.Mutex
+}
+
+func (t *Terminal) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.File.Write(p)
+}
+
+var stdout = &Terminal{File: os.Stdout}
+
+// Stdout returns standard output as a Terminal. The program has to draw to
+// it for copying through the terminal to work while it runs
+func Stdout() *Terminal {
+	return stdout
+}
+
+// CopyText puts text on the clipboard. Over SSH, or when there is no
+// clipboard tool to run, it asks the terminal to do it with an OSC52
+// sequence, which also works inside tmux and screen. It reports whether it
+// did, the terminal doesn't tell whether it copied
+func CopyText(text string) (viaTerminal bool, err error) {
+	if !remoteSession() {
+		if err := clipboard.WriteAll(text); err == nil {
+			return false, nil
+		}
+	}
+
+	seq := osc52.New(text)
+	switch term := os.Getenv("TERM"); {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case strings.HasPrefix(term, "screen"):
+		seq = seq.Screen()
+	}
+	// One write, which the renderer can't interrupt
+	_, err = stdout.Write([]byte(seq.String()))
+	return true, err
+}
+
+// remoteSession reports whether the app runs over SSH, where the clipboard
+// tools would copy to the remote machine
+func remoteSession() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+}
+
+// CopyCmd copies text to the clipboard in the background
+func CopyCmd(origin, what, text string) tea.Cmd {
+	return func() tea.Msg {
+		viaTerminal, err := CopyText(text)
+		return CopiedMsg{Origin: origin, What: what, ViaTerminal: viaTerminal, Err: err}
+	}
+}
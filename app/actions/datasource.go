@@ -0,0 +1,83 @@
+// app/actions/datasource.go
+package actions
+
+import (
+	"bubbletea-app/app/config"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned when no item has the requested ID
+var ErrNotFound = errors.New("item not found")
+
+// Page selects part of the items. A zero Limit means all items from Offset on
+type Page struct {
+	Offset int
+	Limit  int
+}
+
+// ItemList is one page of items along with the total number of items
+type ItemList struct {
+	Items []DataItem
+	Total int
+}
+
+// DataSource stores the items shown on Home. Every backend selectable in
+// Settings implements it, so the pages don't care where items come from
+type DataSource interface {
+	List(ctx context.Context, page Page) (ItemList, error)
+	Get(ctx context.Context, id string) (DataItem, error)
+	// Create stores a new item and returns it with its ID filled in
+	Create(ctx context.Context, item DataItem) (DataItem, error)
+	Update(ctx context.Context, item DataItem) (DataItem, error)
+	Delete(ctx context.Context, id string) error
+}
+
+var (
+	_ DataSource = (*Client)(nil)
+	_ DataSource = (*FileSource)(nil)
+	_ DataSource = (*SQLiteSource)(nil)
+	_ DataSource = (*MockSource)(nil)
+)
+
+// NewDataSource opens the backend selected in settings. Sources that hold
+// resources, like the SQLite one, implement io.Closer
+func NewDataSource(settings config.Settings) (DataSource, error) {
+	switch settings.DataBackend() {
+	case config.BackendREST:
+		client := NewClient(settings)
+		if !client.Configured() {
+			// Nothing to talk to yet, show something to play with
+			return NewMockSource(SampleData()...), nil
+		}
+		return client, nil
+	case config.BackendFile:
+		return NewFileSource(settings.DataFile()), nil
+	case config.BackendSQLite:
+		return OpenSQLiteSource(settings.DataFile())
+	case config.BackendMock:
+		return NewMockSource(SampleData()...), nil
+	}
+	return nil, fmt.Errorf("unknown backend %q", settings.Backend)
+}
+
+// paginate cuts page out of items
+func paginate(items []DataItem, page Page) ItemList {
+	total := len(items)
+	start := min(max(page.Offset, 0), total)
+	end := total
+	if page.Limit > 0 {
+		end = min(start+page.Limit, total)
+	}
+	return ItemList{Items: append([]DataItem{}, items[start:end]...), Total: total}
+}
+
+// newID returns a random ID for items created by local backends
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
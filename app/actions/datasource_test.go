@@ -0,0 +1,152 @@
+// app/actions/datasource_test.go
+package actions_test
+
+import (
+	"bubbletea-app/app/actions"
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// localSources open each local backend holding testItems
+var localSources = []struct {
+	name string
+	open func(t *testing.T) actions.DataSource
+}{
+	{"file", func(t *testing.T) actions.DataSource {
+		path := filepath.Join(t.TempDir(), "items.json")
+		if err := actions.ExportItems(path, testItems); err != nil {
+			t.Fatal(err)
+		}
+		return actions.NewFileSource(path)
+	}},
+	{"mock", func(t *testing.T) actions.DataSource {
+		return actions.NewMockSource(testItems...)
+	}},
+	{"sqlite", func(t *testing.T) actions.DataSource {
+		source, err := actions.OpenSQLiteSource(filepath.Join(t.TempDir(), "items.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { source.Close() })
+		for _, item := range testItems {
+			if _, err := source.Create(context.Background(), item); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return source
+	}},
+}
+
+// ids returns the IDs of items in order
+func ids(items []actions.DataItem) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func TestSourceList(t *testing.T) {
+	tests := []struct {
+		name string
+		page actions.Page
+		want []string
+	}{
+		{"all", actions.Page{}, []string{"1", "2", "3"}},
+		{"first page", actions.Page{Limit: 2}, []string{"1", "2"}},
+		{"last page", actions.Page{Offset: 2, Limit: 2}, []string{"3"}},
+		{"past the end", actions.Page{Offset: 5, Limit: 2}, []string{}},
+	}
+	for _, source := range localSources {
+		for _, tt := range tests {
+			t.Run(source.name+"/"+tt.name, func(t *testing.T) {
+				list, err := source.open(t).List(context.Background(), tt.page)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := ids(list.Items); !slices.Equal(got, tt.want) || list.Total != len(testItems) {
+					t.Errorf("got %v of %d, want %v of %d", got, list.Total, tt.want, len(testItems))
+				}
+				for _, item := range list.Items {
+					for _, want := range testItems {
+						if item.ID == want.ID && !item.Equal(want) {
+							t.Errorf("listed %+v, want %+v", item, want)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestSourceChanges(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		// change changes the source and returns the item it should hold
+		// now under id, or ErrNotFound when it shouldn't
+		change func(source actions.DataSource) (id string, want actions.DataItem, err error)
+	}{
+		{"get", func(source actions.DataSource) (string, actions.DataItem, error) {
+			return "1", testItems[0], nil
+		}},
+		{"get missing", func(source actions.DataSource) (string, actions.DataItem, error) {
+			return "9", actions.DataItem{}, actions.ErrNotFound
+		}},
+		{"create", func(source actions.DataSource) (string, actions.DataItem, error) {
+			created, err := source.Create(ctx, actions.DataItem{Title: "Order flowers", Status: actions.StatusTodo})
+			if err != nil || created.ID == "" {
+				return "", created, errors.New("no ID for the new item")
+			}
+			return created.ID, created, nil
+		}},
+		{"update", func(source actions.DataSource) (string, actions.DataItem, error) {
+			item := testItems[1]
+			item.Title, item.Status = "Book the hall", actions.StatusDone
+			if _, err := source.Update(ctx, item); err != nil {
+				return "", item, err
+			}
+			return item.ID, item, nil
+		}},
+		{"update missing", func(source actions.DataSource) (string, actions.DataItem, error) {
+			if _, err := source.Update(ctx, actions.DataItem{ID: "9", Title: "Nothing"}); !errors.Is(err, actions.ErrNotFound) {
+				return "", actions.DataItem{}, errors.New("updated a missing item")
+			}
+			return "9", actions.DataItem{}, actions.ErrNotFound
+		}},
+		{"delete", func(source actions.DataSource) (string, actions.DataItem, error) {
+			if err := source.Delete(ctx, "2"); err != nil {
+				return "", actions.DataItem{}, err
+			}
+			return "2", actions.DataItem{}, actions.ErrNotFound
+		}},
+		{"delete missing", func(source actions.DataSource) (string, actions.DataItem, error) {
+			if err := source.Delete(ctx, "9"); !errors.Is(err, actions.ErrNotFound) {
+				return "", actions.DataItem{}, errors.New("deleted a missing item")
+			}
+			return "9", actions.DataItem{}, actions.ErrNotFound
+		}},
+	}
+	for _, source := range localSources {
+		for _, tt := range tests {
+			t.Run(source.name+"/"+tt.name, func(t *testing.T) {
+				s := source.open(t)
+				id, want, wantErr := tt.change(s)
+				if wantErr != nil && !errors.Is(wantErr, actions.ErrNotFound) {
+					t.Fatal(wantErr)
+				}
+
+				got, err := s.Get(ctx, id)
+				switch {
+				case !errors.Is(err, wantErr):
+					t.Fatalf("getting %s: %v, want %v", id, err, wantErr)
+				case err == nil && !got.Equal(want):
+					t.Errorf("got %+v, want %+v", got, want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// app/actions/export.go
+package actions
+
+import (
+	"bubbletea-app/app/config"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ExportFormat is a file format items can be exported to, named like its
+// file extension
+type ExportFormat string
+
+// Formats items can be exported to
+const (
+	FormatJSON     ExportFormat = "json"
+	FormatCSV      ExportFormat = "csv"
+	FormatMarkdown ExportFormat = "md"
+)
+
+// ExportFormats lists the export formats
+var ExportFormats = []ExportFormat{FormatJSON, FormatCSV, FormatMarkdown}
+
+func (f ExportFormat) String() string {
+	switch f {
+	case FormatCSV:
+		return "CSV"
+	case FormatMarkdown:
+		return "Markdown"
+	}
+	return "JSON"
+}
+
+// ParseExportFormat returns the format named name, as in ExportFormats.
+// "markdown" works too
+func ParseExportFormat(name string) (ExportFormat, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "markdown" {
+		return FormatMarkdown, nil
+	}
+	for _, format := range ExportFormats {
+		if string(format) == name {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format %q: must be json, csv or md", name)
+}
+
+// FormatOf returns the format of the file at path, by its extension
+func FormatOf(path string) (ExportFormat, error) {
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	if ext == "" {
+		return "", fmt.Errorf("%s has no extension: end it in .json, .csv or .md", path)
+	}
+	return ParseExportFormat(ext)
+}
+
+// ExportDoneMsg reports how writing an export went. Origin is the page
+// that asked for it. Path is empty when the items went to the clipboard,
+// ViaTerminal is set when they went there through the terminal, see
+// CopiedMsg
+type ExportDoneMsg struct {
+	Origin      string
+	Path        string
+	Format      ExportFormat
+	Count       int
+	ViaTerminal bool
+	Err         error
+}
+
+// ExportPath returns a new file name in the exports folder of the config dir
+func ExportPath(format ExportFormat) string {
+	name := "items-" + time.Now().Format("20060102-150405") + "." + string(format)
+	return filepath.Join(config.GetConfigPath(), "exports", name)
+}
+
+// WriteItems writes items to w in format. JSON is a list the file backend
+// can open again, CSV has a header row and Markdown is a table for pasting
+// into reports
+func WriteItems(w io.Writer, format ExportFormat, items []DataItem) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, items)
+	case FormatMarkdown:
+		return writeMarkdown(w, items)
+	}
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+func writeCSV(w io.Writer, items []DataItem) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"id", "title", "description", "status", "created"})
+	for _, item := range items {
+		created := ""
+		if !item.Created.IsZero() {
+			created = item.Created.Format(time.RFC3339)
+		}
+		out.Write([]string{item.ID, item.Title, item.Description, item.ItemStatus(), created})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+func writeMarkdown(w io.Writer, items []DataItem) error {
+	var b strings.Builder
+	b.WriteString("| Title | Status | Description | Created |\n")
+	b.WriteString("| --- | --- | --- | --- |\n")
+	for _, item := range items {
+		created := ""
+		if !item.Created.IsZero() {
+			created = item.Created.Format("2006-01-02")
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n",
+			markdownCell(item.Title), item.ItemStatus(), markdownCell(item.Description), created)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownCell keeps text from breaking out of its table cell
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
+
+// ExportItems writes items to the file at path, in the format its extension
+// names
+func ExportItems(path string, items []DataItem) error {
+	format, err := FormatOf(path)
+	if err != nil {
+		return err
+	}
+	if format == FormatJSON {
+		return NewFileSource(path).write(items)
+	}
+
+	var b bytes.Buffer
+	if err := WriteItems(&b, format, items); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b.Bytes(), 0644)
+}
+
+// CopyItems puts items on the clipboard in format, see CopyText
+func CopyItems(format ExportFormat, items []DataItem) (viaTerminal bool, err error) {
+	var b strings.Builder
+	if err := WriteItems(&b, format, items); err != nil {
+		return false, err
+	}
+	return CopyText(b.String())
+}
+
+// ExportCmd exports items to target in the background. A target that names
+// a format copies the items to the clipboard in it, anything else is a file
+// path whose extension picks the format
+func ExportCmd(origin, target string, items []DataItem) tea.Cmd {
+	return func() tea.Msg {
+		msg := ExportDoneMsg{Origin: origin, Count: len(items)}
+		target = strings.TrimSpace(target)
+		if format, err := ParseExportFormat(target); err == nil {
+			msg.Format = format
+			msg.ViaTerminal, msg.Err = CopyItems(format, items)
+			return msg
+		}
+
+		msg.Path = target
+		msg.Format, _ = FormatOf(target)
+		msg.Err = ExportItems(target, items)
+		return msg
+	}
+}
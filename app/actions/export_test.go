@@ -0,0 +1,98 @@
+// app/actions/export_test.go
+package actions_test
+
+import (
+	"bubbletea-app/app/actions"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// awkwardItem has text that breaks tables and CSV rows unless escaped
+var awkwardItem = actions.DataItem{
+	ID:          "7",
+	Title:       `Pick A | B, "the cheap one"`,
+	Description: "First line\r\nsecond line\nthird",
+	Status:      actions.StatusTodo,
+	Created:     time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC),
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var b strings.Builder
+	if err := actions.WriteItems(&b, actions.FormatMarkdown, []actions.DataItem{awkwardItem, {Title: "No date"}}); err != nil {
+		t.Fatal(err)
+	}
+	want := "| Title | Status | Description | Created |\n" +
+		"| --- | --- | --- | --- |\n" +
+		`| Pick A \| B, "the cheap one" | todo | First line<br>second line<br>third | 2025-02-03 |` + "\n" +
+		"| No date | todo |  |  |\n"
+	if b.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var b strings.Builder
+	if err := actions.WriteItems(&b, actions.FormatCSV, []actions.DataItem{awkwardItem}); err != nil {
+		t.Fatal(err)
+	}
+	// The quoted fields read back as they were
+	rows, err := csv.NewReader(strings.NewReader(b.String())).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"id", "title", "description", "status", "created"},
+		{"7", awkwardItem.Title, "First line\nsecond line\nthird", "todo", "2025-02-03T10:00:00Z"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(want))
+	}
+	for i := range want {
+		if !slices.Equal(rows[i], want[i]) {
+			t.Errorf("row %d is %q, want %q", i, rows[i], want[i])
+		}
+	}
+	if !strings.Contains(b.String(), `"Pick A | B, ""the cheap one"""`) {
+		t.Errorf("the title isn't quoted:\n%s", b.String())
+	}
+}
+
+func TestExportItemsFormat(t *testing.T) {
+	tests := []struct {
+		file string
+		// start is how the file begins
+		start   string
+		wantErr bool
+	}{
+		{"items.json", "[", false},
+		{"items.csv", "id,title,", false},
+		{"items.md", "| Title |", false},
+		{"items.MD", "| Title |", false},
+		{"items.txt", "", true},
+		{"items", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			err := actions.ExportItems(path, testItems)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want one: %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(string(data), tt.start) {
+				t.Errorf("the file starts with %.20q, want %q", data, tt.start)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// app/actions/history.go
+package actions
+
+import "sync"
+
+// DefaultHistoryLimit is how many mutations a store remembers for undo
+const DefaultHistoryLimit = 50
+
+// HistoryOp tells why a mutation is applied
+type HistoryOp int
+
+const (
+	// OpDo is a new change made by the user
+	OpDo HistoryOp = iota
+	// OpUndo reverts the last change
+	OpUndo
+	// OpRedo applies the last undone change again
+	OpRedo
+)
+
+// History records the mutations that reached the backend, so they can be
+// undone and redone. Only one undo or redo runs at a time: Start* locks the
+// history until the backend answered and Finish or Abort is called
+type History struct {
+	mu    sync.Mutex
+	limit int
+	undo  []Mutation
+	redo  []Mutation
+	busy  bool
+}
+
+// NewHistory creates a history that keeps at most limit mutations
+func NewHistory(limit int) *History {
+	return &History{limit: limit}
+}
+
+// Record adds a change made by the user. It drops the oldest change once
+// the history is full and forgets everything that could be redone
+func (h *History) Record(m Mutation) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.undo = append(h.undo, m)
+	if len(h.undo) > h.limit {
+		h.undo = h.undo[len(h.undo)-h.limit:]
+	}
+	h.redo = nil
+}
+
+// StartUndo returns the mutation that reverts the last change
+func (h *History) StartUndo() (Mutation, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.busy || len(h.undo) == 0 {
+		return Mutation{}, false
+	}
+	h.busy = true
+	return h.undo[len(h.undo)-1].Inverse(), true
+}
+
+// StartRedo returns the last undone change
+func (h *History) StartRedo() (Mutation, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.busy || len(h.redo) == 0 {
+		return Mutation{}, false
+	}
+	h.busy = true
+	return h.redo[len(h.redo)-1], true
+}
+
+// Finish moves the change between the undo and redo stacks once the backend
+// accepted op. applied is the mutation as the backend stored it, in case it
+// changed the IDs of recreated items
+func (h *History) Finish(op HistoryOp, applied Mutation) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.busy = false
+	switch op {
+	case OpUndo:
+		if len(h.undo) == 0 {
+			return
+		}
+		h.undo = h.undo[:len(h.undo)-1]
+		h.redo = append(h.redo, applied.Inverse())
+	case OpRedo:
+		if len(h.redo) == 0 {
+			return
+		}
+		h.redo = h.redo[:len(h.redo)-1]
+		h.undo = append(h.undo, applied)
+	}
+}
+
+// Abort unlocks the history after the backend rejected an undo or redo
+func (h *History) Abort() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.busy = false
+}
+
+// Clear forgets all changes
+func (h *History) Clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.undo, h.redo, h.busy = nil, nil, false
+}
+
+// Len returns how many changes can be undone and redone
+func (h *History) Len() (undo, redo int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.undo), len(h.redo)
+}
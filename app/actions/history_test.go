@@ -0,0 +1,174 @@
+// app/actions/history_test.go
+package actions_test
+
+import (
+	"bubbletea-app/app/actions"
+	"bubbletea-app/app/config"
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// update changes the title of testItems[i]
+func update(i int, title string) actions.Mutation {
+	after := testItems[i]
+	after.Title = title
+	return actions.UpdateItem(testItems[i], after, i)
+}
+
+func TestHistory(t *testing.T) {
+	tests := []struct {
+		name string
+		// steps run against a history of three mutations
+		steps    func(h *actions.History)
+		undo     int
+		redo     int
+		nextUndo string
+	}{
+		{"record", func(h *actions.History) {
+			h.Record(update(0, "first"))
+			h.Record(update(0, "second"))
+		}, 2, 0, "second"},
+		{"bounded", func(h *actions.History) {
+			for _, title := range []string{"first", "second", "third", "fourth"} {
+				h.Record(update(0, title))
+			}
+		}, 3, 0, "fourth"},
+		{"undo", func(h *actions.History) {
+			h.Record(update(0, "first"))
+			h.Record(update(0, "second"))
+			undo, _ := h.StartUndo()
+			h.Finish(actions.OpUndo, undo)
+		}, 1, 1, "first"},
+		{"redo", func(h *actions.History) {
+			h.Record(update(0, "first"))
+			undo, _ := h.StartUndo()
+			h.Finish(actions.OpUndo, undo)
+			redo, _ := h.StartRedo()
+			h.Finish(actions.OpRedo, redo)
+		}, 1, 0, "first"},
+		{"push clears redo", func(h *actions.History) {
+			h.Record(update(0, "first"))
+			h.Record(update(0, "second"))
+			undo, _ := h.StartUndo()
+			h.Finish(actions.OpUndo, undo)
+			h.Record(update(0, "third"))
+		}, 2, 0, "third"},
+		{"abort keeps the change", func(h *actions.History) {
+			h.Record(update(0, "first"))
+			h.StartUndo()
+			h.Abort()
+		}, 1, 0, "first"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := actions.NewHistory(3)
+			tt.steps(h)
+			if undo, redo := h.Len(); undo != tt.undo || redo != tt.redo {
+				t.Errorf("got %d to undo and %d to redo, want %d and %d", undo, redo, tt.undo, tt.redo)
+			}
+			undo, ok := h.StartUndo()
+			if !ok {
+				t.Fatal("nothing to undo")
+			}
+			// The undo of an update puts the title back
+			if got := undo.Before.Title; got != tt.nextUndo {
+				t.Errorf("next undo reverts %q, want %q", got, tt.nextUndo)
+			}
+		})
+	}
+}
+
+func TestHistoryOneAtATime(t *testing.T) {
+	h := actions.NewHistory(3)
+	h.Record(update(0, "first"))
+	h.Record(update(1, "second"))
+	if _, ok := h.StartUndo(); !ok {
+		t.Fatal("nothing to undo")
+	}
+	if _, ok := h.StartUndo(); ok {
+		t.Error("started a second undo before the first finished")
+	}
+	h.Abort()
+	if _, ok := h.StartUndo(); !ok {
+		t.Error("still busy after the undo was aborted")
+	}
+}
+
+func TestMutationInverse(t *testing.T) {
+	created := actions.CreateItem(testItems[2], 2)
+	tests := []struct {
+		name     string
+		mutation actions.Mutation
+		want     actions.Mutation
+	}{
+		{"create", created,
+			actions.DeleteItem(created.After, 2)},
+		{"update", update(1, "Book the hall"),
+			actions.Mutation{Kind: actions.MutationUpdate, Before: update(1, "Book the hall").After, After: testItems[1], Index: 1}},
+		{"delete", actions.DeleteItem(testItems[0], 0),
+			actions.Mutation{Kind: actions.MutationCreate, After: testItems[0], Index: 0}},
+		{"batch", actions.Batch(actions.DeleteItem(testItems[2], 2), actions.DeleteItem(testItems[0], 0)),
+			actions.Batch(
+				actions.Mutation{Kind: actions.MutationCreate, After: testItems[0], Index: 0},
+				actions.Mutation{Kind: actions.MutationCreate, After: testItems[2], Index: 2},
+			)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inverse := tt.mutation.Inverse()
+			if !reflect.DeepEqual(inverse, tt.want) {
+				t.Errorf("got %+v, want %+v", inverse, tt.want)
+			}
+			if twice := inverse.Inverse(); !reflect.DeepEqual(twice, tt.mutation) {
+				t.Errorf("the inverse of the inverse is %+v", twice)
+			}
+		})
+	}
+}
+
+func TestHistorySurvivesPageSwitches(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "items.json")
+	if err := actions.ExportItems(path, testItems); err != nil {
+		t.Fatal(err)
+	}
+	settings := config.Settings{Backend: config.BackendFile, DataPath: path}
+	store := actions.NewStore(settings)
+	t.Cleanup(func() { store.Close() })
+
+	// A change made on Home
+	msg := actions.MutateCmd(store, "home", update(0, "Write the summary"), actions.OpDo)()
+	if _, ok := msg.(actions.MutationDoneMsg); !ok {
+		t.Fatalf("got %#v", msg)
+	}
+
+	// Every page reopens the store when it sees the settings, which keeps
+	// the backend and its history
+	store.Reopen(settings)
+	if undo, _ := store.History.Len(); undo != 1 {
+		t.Fatalf("%d changes to undo after reopening", undo)
+	}
+
+	// Another page undoes it
+	undo, ok := store.History.StartUndo()
+	if !ok {
+		t.Fatal("nothing to undo")
+	}
+	if msg := actions.MutateCmd(store, "home-2", undo, actions.OpUndo)(); msg.(actions.MutationDoneMsg).Origin != "home-2" {
+		t.Fatalf("got %#v", msg)
+	}
+	source, _ := store.Source()
+	if item, err := source.Get(context.Background(), "1"); err != nil || item.Title != testItems[0].Title {
+		t.Errorf("item 1 is %+v (%v) after the undo", item, err)
+	}
+	if undo, redo := store.History.Len(); undo != 0 || redo != 1 {
+		t.Errorf("got %d to undo and %d to redo, want 0 and 1", undo, redo)
+	}
+
+	// Another backend starts over
+	store.Reopen(config.Settings{Backend: config.BackendMock})
+	if undo, redo := store.History.Len(); undo != 0 || redo != 0 {
+		t.Errorf("kept %d to undo and %d to redo on another backend", undo, redo)
+	}
+}
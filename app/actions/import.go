@@ -0,0 +1,322 @@
+// app/actions/import.go
+package actions
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ImportRow is an item read from an import file, with what is wrong with it
+type ImportRow struct {
+	// Line is where the item is in the file: the line for CSV, where the
+	// header is line 1, and the position in the list for JSON
+	Line int
+	Item DataItem
+	// Err tells why the row can't be imported
+	Err error
+	// Existing is the item in the backend with the same ID, if any
+	Existing *DataItem
+}
+
+// ConflictMode tells what an import does with items the backend has already
+type ConflictMode int
+
+const (
+	// ConflictSkip keeps the item in the backend as it is
+	ConflictSkip ConflictMode = iota
+	// ConflictOverwrite replaces the item in the backend
+	ConflictOverwrite
+	// ConflictMerge takes the fields the imported item has, and keeps the
+	// rest
+	ConflictMerge
+)
+
+// ConflictModes lists the conflict modes in order
+var ConflictModes = []ConflictMode{ConflictSkip, ConflictOverwrite, ConflictMerge}
+
+func (c ConflictMode) String() string {
+	switch c {
+	case ConflictOverwrite:
+		return "Overwrite"
+	case ConflictMerge:
+		return "Merge"
+	}
+	return "Skip"
+}
+
+// ImportResult counts what an import did. Errors tell why rows failed
+type ImportResult struct {
+	Created, Updated, Skipped, Failed int
+	Errors                            []error
+}
+
+// ImportPreviewMsg carries the rows read from Path for the page with id
+// Origin to show before importing them
+type ImportPreviewMsg struct {
+	Origin string
+	Path   string
+	Rows   []ImportRow
+	Err    error
+}
+
+// ImportDoneMsg reports a finished import. Applied holds the changes that
+// went through as one batch, for pages to show them and undo them as one
+type ImportDoneMsg struct {
+	Origin  string
+	Path    string
+	Result  ImportResult
+	Applied Mutation
+	Err     error
+}
+
+// ReadImport reads the items in the JSON or CSV file at path and checks
+// them. JSON is a list like the export writes, CSV needs a header row with a
+// title column, and may have id, description, status and created columns
+func ReadImport(path string) ([]ImportRow, error) {
+	format, err := FormatOf(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var rows []ImportRow
+	switch format {
+	case FormatJSON:
+		rows, err = readJSONRows(file)
+	case FormatCSV:
+		rows, err = readCSVRows(file)
+	default:
+		return nil, fmt.Errorf("%s can't be imported, only JSON and CSV can", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s has no items", path)
+	}
+
+	seen := map[string]int{}
+	for i := range rows {
+		if rows[i].Err == nil {
+			rows[i].Err = validateImport(rows[i].Item)
+		}
+		id := rows[i].Item.ID
+		if id == "" {
+			continue
+		}
+		if line, ok := seen[id]; !ok {
+			seen[id] = rows[i].Line
+		} else if rows[i].Err == nil {
+			rows[i].Err = fmt.Errorf("ID %s is on line %d already", id, line)
+		}
+	}
+	return rows, nil
+}
+
+func readJSONRows(r io.Reader) ([]ImportRow, error) {
+	var items []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("reading JSON: %w", err)
+	}
+	rows := make([]ImportRow, len(items))
+	for i, raw := range items {
+		rows[i].Line = i + 1
+		if err := json.Unmarshal(raw, &rows[i].Item); err != nil {
+			rows[i].Err = fmt.Errorf("not an item: %w", err)
+		}
+	}
+	return rows, nil
+}
+
+func readCSVRows(r io.Reader) ([]ImportRow, error) {
+	in := csv.NewReader(r)
+	in.FieldsPerRecord = -1
+	header, err := in.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("the CSV header has no title column")
+	}
+
+	var rows []ImportRow
+	for {
+		record, err := in.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading CSV: %w", err)
+		}
+		line, _ := in.FieldPos(0)
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := ImportRow{Line: line, Item: DataItem{
+			ID:          field("id"),
+			Title:       field("title"),
+			Description: field("description"),
+			Status:      strings.ToLower(field("status")),
+		}}
+		if created := field("created"); created != "" {
+			row.Item.Created, row.Err = parseCreated(created)
+		}
+		rows = append(rows, row)
+	}
+}
+
+// parseCreated reads a creation time as the CSV export writes it, or just
+// a date
+func parseCreated(value string) (time.Time, error) {
+	if created, err := time.Parse(time.RFC3339, value); err == nil {
+		return created, nil
+	}
+	if created, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return created, nil
+	}
+	return time.Time{}, fmt.Errorf("created %q is not a date", value)
+}
+
+// validateImport checks that item can be stored
+func validateImport(item DataItem) error {
+	if strings.TrimSpace(item.Title) == "" {
+		return errors.New("title is missing")
+	}
+	if item.Status != "" && !slices.Contains(Statuses, item.Status) {
+		return fmt.Errorf("unknown status %q", item.Status)
+	}
+	return nil
+}
+
+// MergeItems returns existing with the fields imported has set
+func MergeItems(existing, imported DataItem) DataItem {
+	if imported.Title != "" {
+		existing.Title = imported.Title
+	}
+	if imported.Description != "" {
+		existing.Description = imported.Description
+	}
+	if imported.Status != "" {
+		existing.Status = imported.Status
+	}
+	if !imported.Created.IsZero() {
+		existing.Created = imported.Created
+	}
+	return existing
+}
+
+// ImportPreviewCmd reads the file at path in the background and looks up
+// the items the store's backend has already, with one listing of all items
+func ImportPreviewCmd(store *Store, origin, path string) tea.Cmd {
+	return func() tea.Msg {
+		msg := ImportPreviewMsg{Origin: origin, Path: path}
+		source, err := store.Source()
+		if err == nil {
+			msg.Rows, err = ReadImport(path)
+		}
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+
+		list, err := source.List(context.Background(), Page{})
+		if err != nil {
+			err = fmt.Errorf("could not look for the item: %w", err)
+		}
+		stored := make(map[string]DataItem, len(list.Items))
+		for _, item := range list.Items {
+			stored[item.ID] = item
+		}
+		for i, row := range msg.Rows {
+			if row.Err != nil || row.Item.ID == "" {
+				continue
+			}
+			if err != nil {
+				msg.Rows[i].Err = err
+			} else if existing, ok := stored[row.Item.ID]; ok {
+				msg.Rows[i].Existing = &existing
+			}
+		}
+		return msg
+	}
+}
+
+// ImportCmd stores the valid rows in the store's backend in the background.
+// Items it has already are handled as mode says. Rows that fail don't stop
+// the others, and the ones that went through are undone as one change
+func ImportCmd(store *Store, origin, path string, rows []ImportRow, mode ConflictMode) tea.Cmd {
+	return func() tea.Msg {
+		msg := ImportDoneMsg{Origin: origin, Path: path}
+		source, err := store.Source()
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+
+		var applied []Mutation
+		for _, row := range rows {
+			if row.Err != nil {
+				msg.Result.Failed++
+				msg.Result.Errors = append(msg.Result.Errors, fmt.Errorf("line %d: %w", row.Line, row.Err))
+				continue
+			}
+			// New items go last, like the backends add them
+			mutation := CreateItem(row.Item, math.MaxInt)
+			if row.Existing != nil {
+				switch mode {
+				case ConflictSkip:
+					msg.Result.Skipped++
+					continue
+				case ConflictOverwrite:
+					mutation = UpdateItem(*row.Existing, row.Item, -1)
+				case ConflictMerge:
+					mutation = UpdateItem(*row.Existing, MergeItems(*row.Existing, row.Item), -1)
+				}
+			}
+
+			stored, err := mutation.Apply(context.Background(), source)
+			if err != nil {
+				msg.Result.Failed++
+				msg.Result.Errors = append(msg.Result.Errors, fmt.Errorf("line %d: %w", row.Line, err))
+				continue
+			}
+			if stored.Kind == MutationCreate {
+				msg.Result.Created++
+			} else {
+				msg.Result.Updated++
+			}
+			applied = append(applied, stored)
+		}
+
+		msg.Applied = Batch(applied...)
+		if len(applied) > 0 {
+			// Cached pages miss the items now
+			expireCache(store.Settings())
+			store.History.Record(msg.Applied)
+		}
+		return msg
+	}
+}
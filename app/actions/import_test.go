@@ -0,0 +1,104 @@
+// app/actions/import_test.go
+package actions_test
+
+import (
+	"bubbletea-app/app/actions"
+	"bubbletea-app/app/config"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestImportPreviewLooksUpOnce(t *testing.T) {
+	server := newServer(t, "")
+	store := actions.NewStore(server.Settings())
+	t.Cleanup(func() { store.Close() })
+	source, err := store.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	source.(*actions.Client).Retry = quickRetry
+
+	path := filepath.Join(t.TempDir(), "items.json")
+	rows := []actions.DataItem{
+		{ID: "1", Title: "Write the report", Status: actions.StatusDone},
+		{ID: "9", Title: "Order flowers", Status: actions.StatusTodo},
+		{ID: "3", Title: "Send invites", Status: actions.StatusTodo},
+		{Title: "No ID yet", Status: actions.StatusTodo},
+	}
+	if err := actions.ExportItems(path, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := actions.ImportPreviewCmd(store, "home", path)().(actions.ImportPreviewMsg)
+	if msg.Err != nil {
+		t.Fatal(msg.Err)
+	}
+	if got := server.Requests(); got != 1 {
+		t.Errorf("sent %d requests for %d rows, want 1", got, len(rows))
+	}
+	for i, want := range []string{"1", "", "3", ""} {
+		row := msg.Rows[i]
+		switch {
+		case row.Err != nil:
+			t.Errorf("row %d: %v", i, row.Err)
+		case want == "" && row.Existing != nil:
+			t.Errorf("row %d is in the backend as %+v", i, *row.Existing)
+		case want != "" && (row.Existing == nil || row.Existing.ID != want):
+			t.Errorf("row %d isn't matched with item %s", i, want)
+		}
+	}
+
+	// Rows can't be checked while the backend is failing
+	server.FailNext(10, http.StatusInternalServerError)
+	msg = actions.ImportPreviewCmd(store, "home", path)().(actions.ImportPreviewMsg)
+	for i, row := range msg.Rows {
+		if (row.Err != nil) != (row.Item.ID != "") {
+			t.Errorf("row %d with ID %q: error %v", i, row.Item.ID, row.Err)
+		}
+	}
+}
+
+func TestReadImportDuplicates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "items.csv")
+	data := "id,title,status\n" +
+		"4,Order flowers,todo\n" +
+		"4,Order cake,todo\n" +
+		"5,Order chairs,todo\n" +
+		"4,Order balloons,todo\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := actions.ReadImport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"", "ID 4 is on line 2 already", "", "ID 4 is on line 2 already"}
+	for i, row := range rows {
+		got := ""
+		if row.Err != nil {
+			got = row.Err.Error()
+		}
+		if got != want[i] {
+			t.Errorf("row %d: got error %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+func TestImportReportsInvalidRows(t *testing.T) {
+	store := actions.NewStore(config.Settings{Backend: config.BackendMock})
+	t.Cleanup(func() { store.Close() })
+	rows := []actions.ImportRow{
+		{Line: 1, Item: actions.DataItem{Title: "Order flowers", Status: actions.StatusTodo}},
+		{Line: 2, Item: actions.DataItem{Status: actions.StatusTodo}, Err: errors.New("no title")},
+	}
+	msg := actions.ImportCmd(store, "home", "items.csv", rows, actions.ConflictSkip)().(actions.ImportDoneMsg)
+	if msg.Result.Created != 1 || msg.Result.Failed != 1 {
+		t.Fatalf("got %+v, want one created and one failed", msg.Result)
+	}
+	if len(msg.Result.Errors) != 1 || msg.Result.Errors[0].Error() != "line 2: no title" {
+		t.Errorf("got errors %v, want the reason line 2 failed", msg.Result.Errors)
+	}
+}
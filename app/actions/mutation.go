@@ -0,0 +1,210 @@
+// app/actions/mutation.go
+package actions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// MutationKind tells what a mutation does to an item
+type MutationKind int
+
+const (
+	MutationCreate MutationKind = iota
+	MutationUpdate
+	MutationDelete
+	// MutationBatch applies Children in order, as one change
+	MutationBatch
+)
+
+// Mutation is a single reversible change to the items. Pages apply it to
+// their copy right away and roll it back if the backend rejects it
+type Mutation struct {
+	Kind MutationKind
+	// Before is the item as it was, for updates and deletes
+	Before DataItem
+	// After is the item as it should be, for creates and updates
+	After DataItem
+	// Index is the list position of the item, used to put it back in place
+	Index int
+	// Children are the mutations of a batch
+	Children []Mutation
+}
+
+// CreateItem returns a mutation that adds item at index. Items without an
+// ID get one, so the page can find the item again once the backend answers
+func CreateItem(item DataItem, index int) Mutation {
+	if item.ID == "" {
+		item.ID = newID()
+	}
+	if item.Created.IsZero() {
+		item.Created = time.Now()
+	}
+	return Mutation{Kind: MutationCreate, After: item, Index: index}
+}
+
+// UpdateItem returns a mutation that changes before into after
+func UpdateItem(before, after DataItem, index int) Mutation {
+	after.ID = before.ID
+	return Mutation{Kind: MutationUpdate, Before: before, After: after, Index: index}
+}
+
+// DeleteItem returns a mutation that removes item from index
+func DeleteItem(item DataItem, index int) Mutation {
+	return Mutation{Kind: MutationDelete, Before: item, Index: index}
+}
+
+// Batch returns a mutation that applies mutations in order and is undone as
+// a whole. Deletes should come last index first, so their indexes stay
+// valid, and so should the creates of the undo
+func Batch(mutations ...Mutation) Mutation {
+	return Mutation{Kind: MutationBatch, Children: mutations}
+}
+
+// Inverse returns the mutation that undoes m
+func (m Mutation) Inverse() Mutation {
+	switch m.Kind {
+	case MutationCreate:
+		return Mutation{Kind: MutationDelete, Before: m.After, Index: m.Index}
+	case MutationDelete:
+		return Mutation{Kind: MutationCreate, After: m.Before, Index: m.Index}
+	case MutationBatch:
+		children := make([]Mutation, len(m.Children))
+		for i, child := range m.Children {
+			children[len(children)-1-i] = child.Inverse()
+		}
+		return Batch(children...)
+	default:
+		return Mutation{Kind: MutationUpdate, Before: m.After, After: m.Before, Index: m.Index}
+	}
+}
+
+// Item returns the item the mutation is about, the first one for a batch
+func (m Mutation) Item() DataItem {
+	switch m.Kind {
+	case MutationDelete:
+		return m.Before
+	case MutationBatch:
+		if len(m.Children) == 0 {
+			return DataItem{}
+		}
+		return m.Children[0].Item()
+	}
+	return m.After
+}
+
+// Leaves returns the single item mutations m is made of
+func (m Mutation) Leaves() []Mutation {
+	if m.Kind != MutationBatch {
+		return []Mutation{m}
+	}
+	var leaves []Mutation
+	for _, child := range m.Children {
+		leaves = append(leaves, child.Leaves()...)
+	}
+	return leaves
+}
+
+// Subject names what the mutation changes, like "'Task 2'" or "3 items"
+func (m Mutation) Subject() string {
+	if leaves := m.Leaves(); len(leaves) != 1 {
+		return fmt.Sprintf("%d items", len(leaves))
+	}
+	return fmt.Sprintf("'%s'", m.Item().Title)
+}
+
+// Describe returns a short past tense description, like "Deleted 'Task 2'".
+// A batch of different kinds of changes, like an import, "Changed" items
+func (m Mutation) Describe() string {
+	kind := m.Kind
+	if leaves := m.Leaves(); len(leaves) > 0 {
+		kind = leaves[0].Kind
+		for _, leaf := range leaves[1:] {
+			if leaf.Kind != kind {
+				kind = MutationBatch
+			}
+		}
+	}
+	verb := map[MutationKind]string{
+		MutationCreate: "Added",
+		MutationUpdate: "Updated",
+		MutationDelete: "Deleted",
+		MutationBatch:  "Changed",
+	}[kind]
+	return verb + " " + m.Subject()
+}
+
+// Apply sends the mutation to source. It returns m with the items as the
+// backend stored them. A batch that fails halfway is rolled back as far as
+// the backend allows
+func (m Mutation) Apply(ctx context.Context, source DataSource) (Mutation, error) {
+	var err error
+	switch m.Kind {
+	case MutationCreate:
+		m.After, err = source.Create(ctx, m.After)
+	case MutationUpdate:
+		m.After, err = source.Update(ctx, m.After)
+	case MutationDelete:
+		err = source.Delete(ctx, m.Before.ID)
+	case MutationBatch:
+		m.Children = slices.Clone(m.Children)
+		for i, child := range m.Children {
+			if m.Children[i], err = child.Apply(ctx, source); err != nil {
+				Batch(m.Children[:i]...).Inverse().Apply(ctx, source)
+				break
+			}
+		}
+	}
+	return m, err
+}
+
+// MutationDoneMsg reports a mutation the backend accepted. Origin is the
+// page that made it, which has already applied it. Stored is Mutation with
+// the items as the backend stored them, for example with its own IDs
+type MutationDoneMsg struct {
+	Origin   string
+	Op       HistoryOp
+	Mutation Mutation
+	Stored   Mutation
+}
+
+// MutationFailedMsg reports a mutation the backend rejected. The page that
+// made it should roll it back
+type MutationFailedMsg struct {
+	Origin   string
+	Op       HistoryOp
+	Mutation Mutation
+	Err      error
+}
+
+// MutateCmd applies mutation to the store's backend in the background and
+// keeps the store's history up to date. op tells whether mutation is a new
+// change or comes from History.StartUndo or StartRedo
+func MutateCmd(store *Store, origin string, mutation Mutation, op HistoryOp) tea.Cmd {
+	return func() tea.Msg {
+		source, err := store.Source()
+		if err == nil {
+			var stored Mutation
+			stored, err = mutation.Apply(context.Background(), source)
+			if err == nil {
+				// Cached pages miss the change now
+				expireCache(store.Settings())
+				if op == OpDo {
+					store.History.Record(stored)
+				} else {
+					store.History.Finish(op, stored)
+				}
+				return MutationDoneMsg{Origin: origin, Op: op, Mutation: mutation, Stored: stored}
+			}
+		}
+
+		if op != OpDo {
+			store.History.Abort()
+		}
+		return MutationFailedMsg{Origin: origin, Op: op, Mutation: mutation, Err: err}
+	}
+}
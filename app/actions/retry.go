@@ -0,0 +1,70 @@
+// app/actions/retry.go
+package actions
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy tells how often and how patiently a failed request is sent
+// again. Delays double from BaseDelay up to MaxDelay
+type RetryPolicy struct {
+	// MaxAttempts counts the first try as well, 1 turns retrying off
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// Jitter is the share of each delay that is random, from 0 to 1. It
+	// keeps clients that failed together from retrying together
+	Jitter float64
+}
+
+// DefaultRetryPolicy is used for the idempotent requests of the API client
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   250 * time.Millisecond,
+	MaxDelay:    4 * time.Second,
+	Jitter:      0.5,
+}
+
+// NoRetry sends requests once. Creating items is not idempotent, a retry
+// after a lost answer could add the item twice
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// Backoff returns how long to wait before retry number retry, counting
+// from 1
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < retry && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, p.MaxDelay)
+	return delay - time.Duration(rand.Float64()*p.Jitter*float64(delay))
+}
+
+// retryable reports whether a request that failed with err may get through
+// when sent again
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	return Unreachable(err)
+}
+
+// sleep waits for d, or less when ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,170 @@
+// app/actions/retry_test.go
+package actions_test
+
+import (
+	"bubbletea-app/app/actions"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// quickRetry retries like the default policy, only fast enough for tests
+var quickRetry = actions.RetryPolicy{MaxAttempts: 3, BaseDelay: 5 * time.Millisecond, MaxDelay: 20 * time.Millisecond}
+
+func TestBackoff(t *testing.T) {
+	policy := actions.RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+	for retry, want := range []time.Duration{10, 20, 40, 50, 50} {
+		if got := policy.Backoff(retry + 1); got != want*time.Millisecond {
+			t.Errorf("Backoff(%d) = %s, want %s", retry+1, got, want*time.Millisecond)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for range 20 {
+		if got := policy.Backoff(2); got <= 10*time.Millisecond || got > 20*time.Millisecond {
+			t.Fatalf("Backoff(2) with jitter = %s, want more than 10ms up to 20ms", got)
+		}
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		failures int
+		method   string
+		requests int
+		fails    bool
+	}{
+		{"server error", http.StatusInternalServerError, 2, http.MethodGet, 3, false},
+		{"unavailable", http.StatusServiceUnavailable, 1, http.MethodGet, 2, false},
+		{"too many requests", http.StatusTooManyRequests, 2, http.MethodGet, 3, false},
+		{"gives up", http.StatusInternalServerError, 5, http.MethodGet, 3, true},
+		{"bad request", http.StatusBadRequest, 1, http.MethodGet, 1, true},
+		{"not found", http.StatusNotFound, 1, http.MethodGet, 1, true},
+		{"create is sent once", http.StatusServiceUnavailable, 1, http.MethodPost, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newServer(t, "")
+			client := newClient(server)
+			client.Retry = quickRetry
+			server.FailNext(tt.failures, tt.status)
+
+			var err error
+			start := time.Now()
+			if tt.method == http.MethodPost {
+				_, err = client.Create(context.Background(), actions.DataItem{Title: "Order flowers"})
+			} else {
+				_, err = client.List(context.Background(), actions.Page{})
+			}
+			if (err != nil) != tt.fails {
+				t.Errorf("got %v, want failing %t", err, tt.fails)
+			}
+			if got := server.Requests(); got != tt.requests {
+				t.Errorf("sent %d requests, want %d", got, tt.requests)
+			}
+			// Retries wait 5ms and then 10ms, the jitter is off
+			var waited time.Duration
+			for retry := 1; retry < tt.requests; retry++ {
+				waited += quickRetry.Backoff(retry)
+			}
+			if elapsed := time.Since(start); elapsed < waited {
+				t.Errorf("retried after %s, want a backoff of %s", elapsed, waited)
+			}
+			if health := client.Health(); health.Retry != 0 {
+				t.Errorf("still waiting for retry %d", health.Retry)
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	server := newServer(t, "")
+	client := newClient(server)
+	client.Breaker = actions.NewCircuitBreaker(2, 30*time.Millisecond)
+	ctx := context.Background()
+
+	server.FailNext(3, http.StatusServiceUnavailable)
+	for range 2 {
+		if _, err := client.List(ctx, actions.Page{}); err == nil {
+			t.Fatal("a failing request got through")
+		}
+	}
+	if state, _ := client.Breaker.State(); state != actions.CircuitOpen {
+		t.Fatalf("state after 2 failures is %d, want open", state)
+	}
+	if _, err := client.List(ctx, actions.Page{}); !errors.Is(err, actions.ErrCircuitOpen) {
+		t.Fatalf("got %v while open, want ErrCircuitOpen", err)
+	}
+	if got := server.Requests(); got != 2 {
+		t.Errorf("sent %d requests, the open circuit should have kept the third", got)
+	}
+
+	// A failing probe opens the circuit again for another cooldown
+	time.Sleep(40 * time.Millisecond)
+	if state, _ := client.Breaker.State(); state != actions.CircuitHalfOpen {
+		t.Fatalf("state after the cooldown is %d, want half open", state)
+	}
+	if _, err := client.List(ctx, actions.Page{}); err == nil || errors.Is(err, actions.ErrCircuitOpen) {
+		t.Fatalf("got %v, want the probe to fail at the server", err)
+	}
+	if state, _ := client.Breaker.State(); state != actions.CircuitOpen {
+		t.Fatalf("state after a failed probe is %d, want open", state)
+	}
+
+	// A probe that gets through closes it
+	time.Sleep(40 * time.Millisecond)
+	if _, err := client.List(ctx, actions.Page{}); err != nil {
+		t.Fatal(err)
+	}
+	if state, _ := client.Breaker.State(); state != actions.CircuitClosed {
+		t.Errorf("state after a good probe is %d, want closed", state)
+	}
+}
+
+func TestCircuitBreakerCanceledProbe(t *testing.T) {
+	server := newServer(t, "")
+	client := newClient(server)
+	client.Breaker = actions.NewCircuitBreaker(1, 20*time.Millisecond)
+
+	server.FailNext(1, http.StatusServiceUnavailable)
+	if _, err := client.List(context.Background(), actions.Page{}); err == nil {
+		t.Fatal("a failing request got through")
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	// The probe hangs, and only one request may be on its way
+	server.SetDelay(time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := client.List(ctx, actions.Page{})
+		done <- err
+	}()
+	for server.Requests() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := client.List(context.Background(), actions.Page{}); !errors.Is(err, actions.ErrCircuitOpen) {
+		t.Errorf("got %v during the probe, want ErrCircuitOpen", err)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("the probe ended with %v, want it canceled", err)
+	}
+
+	// Canceling tells nothing about the host, the next request probes it
+	server.SetDelay(0)
+	if state, _ := client.Breaker.State(); state != actions.CircuitHalfOpen {
+		t.Errorf("state after the canceled probe is %d, want half open", state)
+	}
+	if _, err := client.List(context.Background(), actions.Page{}); err != nil {
+		t.Fatalf("the next probe: %v", err)
+	}
+	if state, _ := client.Breaker.State(); state != actions.CircuitClosed {
+		t.Errorf("state after a good probe is %d, want closed", state)
+	}
+}
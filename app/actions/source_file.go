@@ -0,0 +1,136 @@
+// app/actions/source_file.go
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// FileSource stores items as a JSON list in a local file, handy for offline
+// fixtures. The file is read on every call, so edits made by hand show up
+// on the next refresh
+type FileSource struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// NewFileSource creates a source for the JSON file at path. A missing file
+// counts as no items and is created on the first write
+func NewFileSource(path string) *FileSource {
+	return &FileSource{Path: path}
+}
+
+func (s *FileSource) List(ctx context.Context, page Page) (ItemList, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items, err := s.read()
+	if err != nil {
+		return ItemList{}, err
+	}
+	return paginate(items, page), nil
+}
+
+func (s *FileSource) Get(ctx context.Context, id string) (DataItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items, err := s.read()
+	if err != nil {
+		return DataItem{}, err
+	}
+	i := indexOf(items, id)
+	if i < 0 {
+		return DataItem{}, ErrNotFound
+	}
+	return items[i], nil
+}
+
+func (s *FileSource) Create(ctx context.Context, item DataItem) (DataItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items, err := s.read()
+	if err != nil {
+		return DataItem{}, err
+	}
+	if item.ID == "" {
+		item.ID = newID()
+	}
+	return item, s.write(append(items, item))
+}
+
+func (s *FileSource) Update(ctx context.Context, item DataItem) (DataItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items, err := s.read()
+	if err != nil {
+		return DataItem{}, err
+	}
+	i := indexOf(items, item.ID)
+	if i < 0 {
+		return DataItem{}, ErrNotFound
+	}
+	items[i] = item
+	return item, s.write(items)
+}
+
+func (s *FileSource) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items, err := s.read()
+	if err != nil {
+		return err
+	}
+	i := indexOf(items, id)
+	if i < 0 {
+		return ErrNotFound
+	}
+	return s.write(slices.Delete(items, i, i+1))
+}
+
+func (s *FileSource) read() ([]DataItem, error) {
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var items []DataItem
+	err = json.Unmarshal(data, &items)
+	return items, err
+}
+
+// write replaces the file through a temporary one, so a crash never leaves
+// half a list behind
+func (s *FileSource) write(items []DataItem) error {
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := s.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.Path)
+}
+
+// indexOf returns the position of the item with id, or -1
+func indexOf(items []DataItem, id string) int {
+	return slices.IndexFunc(items, func(item DataItem) bool {
+		return item.ID == id
+	})
+}
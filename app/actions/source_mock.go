@@ -0,0 +1,80 @@
+// app/actions/source_mock.go
+package actions
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// MockSource keeps items in memory. It backs the "mock" setting and stands
+// in for a real backend when nothing is configured
+type MockSource struct {
+	mu    sync.Mutex
+	items []DataItem
+}
+
+// NewMockSource creates a mock source holding items. Items without an ID get
+// one
+func NewMockSource(items ...DataItem) *MockSource {
+	s := &MockSource{}
+	for _, item := range items {
+		if item.ID == "" {
+			item.ID = newID()
+		}
+		s.items = append(s.items, item)
+	}
+	return s
+}
+
+func (s *MockSource) List(ctx context.Context, page Page) (ItemList, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return paginate(s.items, page), nil
+}
+
+func (s *MockSource) Get(ctx context.Context, id string) (DataItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := indexOf(s.items, id)
+	if i < 0 {
+		return DataItem{}, ErrNotFound
+	}
+	return s.items[i], nil
+}
+
+func (s *MockSource) Create(ctx context.Context, item DataItem) (DataItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if item.ID == "" {
+		item.ID = newID()
+	}
+	s.items = append(s.items, item)
+	return item, nil
+}
+
+func (s *MockSource) Update(ctx context.Context, item DataItem) (DataItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := indexOf(s.items, item.ID)
+	if i < 0 {
+		return DataItem{}, ErrNotFound
+	}
+	s.items[i] = item
+	return item, nil
+}
+
+func (s *MockSource) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := indexOf(s.items, id)
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.items = slices.Delete(s.items, i, i+1)
+	return nil
+}
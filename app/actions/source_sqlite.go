@@ -0,0 +1,167 @@
+// app/actions/source_sqlite.go
+package actions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteSource stores items in an embedded SQLite database
+type SQLiteSource struct {
+	db *sql.DB
+}
+
+// OpenSQLiteSource opens the database at path, creating it and the items
+// table if needed. Close it when done
+func OpenSQLiteSource(path string) (*SQLiteSource, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+
+	// seq keeps items in the order they were created
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS items (
+		seq INTEGER PRIMARY KEY AUTOINCREMENT,
+		id TEXT NOT NULL UNIQUE,
+		title TEXT NOT NULL,
+		description TEXT NOT NULL
+	)`)
+	if err == nil {
+		err = migrateSQLite(db)
+	}
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLiteSource{db: db}, nil
+}
+
+// sqliteColumns are the columns added after the items table first shipped
+var sqliteColumns = map[string]string{
+	"status":  `TEXT NOT NULL DEFAULT ''`,
+	"created": `TIMESTAMP NOT NULL DEFAULT '0001-01-01 00:00:00+00:00'`,
+}
+
+// migrateSQLite adds the columns databases made by older versions lack
+func migrateSQLite(db *sql.DB) error {
+	rows, err := db.Query(`SELECT name FROM pragma_table_info('items')`)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for name, definition := range sqliteColumns {
+		if existing[name] {
+			continue
+		}
+		if _, err := db.Exec(`ALTER TABLE items ADD COLUMN ` + name + ` ` + definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the database
+func (s *SQLiteSource) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteSource) List(ctx context.Context, page Page) (ItemList, error) {
+	var list ItemList
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM items`).Scan(&list.Total); err != nil {
+		return ItemList{}, err
+	}
+
+	// SQLite reads a negative limit as no limit
+	limit := page.Limit
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, title, description, status, created FROM items ORDER BY seq LIMIT ? OFFSET ?`,
+		limit, max(page.Offset, 0))
+	if err != nil {
+		return ItemList{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item DataItem
+		if err := rows.Scan(&item.ID, &item.Title, &item.Description, &item.Status, &item.Created); err != nil {
+			return ItemList{}, err
+		}
+		list.Items = append(list.Items, item)
+	}
+	return list, rows.Err()
+}
+
+func (s *SQLiteSource) Get(ctx context.Context, id string) (DataItem, error) {
+	item := DataItem{ID: id}
+	err := s.db.QueryRowContext(ctx,
+		`SELECT title, description, status, created FROM items WHERE id = ?`, id).
+		Scan(&item.Title, &item.Description, &item.Status, &item.Created)
+	if errors.Is(err, sql.ErrNoRows) {
+		return DataItem{}, ErrNotFound
+	}
+	return item, err
+}
+
+func (s *SQLiteSource) Create(ctx context.Context, item DataItem) (DataItem, error) {
+	if item.ID == "" {
+		item.ID = newID()
+	}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO items (id, title, description, status, created) VALUES (?, ?, ?, ?, ?)`,
+		item.ID, item.Title, item.Description, item.Status, item.Created)
+	return item, err
+}
+
+func (s *SQLiteSource) Update(ctx context.Context, item DataItem) (DataItem, error) {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE items SET title = ?, description = ?, status = ? WHERE id = ?`,
+		item.Title, item.Description, item.Status, item.ID)
+	if err != nil {
+		return DataItem{}, err
+	}
+	return item, notFoundIfNone(res)
+}
+
+func (s *SQLiteSource) Delete(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM items WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	return notFoundIfNone(res)
+}
+
+// notFoundIfNone turns a statement that touched no rows into ErrNotFound
+func notFoundIfNone(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,369 @@
+// app/actions/standin/standin.go
+
+// Package standin runs a local stand-in for the data API, so the actions
+// client can be exercised offline
+package standin
+
+import (
+	"bubbletea-app/app/actions"
+	"bubbletea-app/app/config"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Server is an in-process HTTP server that serves items like the real API.
+// Items are kept in an actions.MockSource. Changes made through the API are
+// streamed to every client listening for events, and a client that
+// reconnects with Last-Event-ID gets the events it missed
+type Server struct {
+	*httptest.Server
+	APIKey string
+
+	mu          sync.Mutex
+	source      *actions.MockSource
+	subscribers map[chan actions.StreamEvent]bool
+	lastEvent   int
+	history     []actions.StreamEvent
+	done        chan struct{}
+	requests    int
+	failures    int
+	failStatus  int
+	delay       time.Duration
+	noTotal     bool
+	assignIDs   bool
+	createdIDs  int
+}
+
+// New starts a stand-in server. When apiKey is not empty, requests must
+// send it as a bearer token
+func New(apiKey string, items ...actions.DataItem) *Server {
+	s := &Server{
+		APIKey:      apiKey,
+		source:      actions.NewMockSource(items...),
+		subscribers: map[chan actions.StreamEvent]bool{},
+		done:        make(chan struct{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /items", s.listItems)
+	mux.HandleFunc("GET "+actions.StreamPath, s.streamEvents)
+	mux.HandleFunc("POST /items", s.createItem)
+	mux.HandleFunc("GET /items/{id}", s.getItem)
+	mux.HandleFunc("PUT /items/{id}", s.updateItem)
+	mux.HandleFunc("DELETE /items/{id}", s.deleteItem)
+	s.Server = httptest.NewServer(s.inject(s.authorize(mux)))
+	return s
+}
+
+// Settings returns settings that point the client at this server
+func (s *Server) Settings() config.Settings {
+	u, _ := url.Parse(s.URL)
+	return config.Settings{
+		Backend: config.BackendREST,
+		Host:    u.Hostname(),
+		Port:    u.Port(),
+		APIKey:  s.APIKey,
+	}
+}
+
+// SetItems replaces the items the server returns
+func (s *Server) SetItems(items ...actions.DataItem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.source = actions.NewMockSource(items...)
+}
+
+// Close ends the event streams, which would keep it waiting otherwise, and
+// shuts the server down
+func (s *Server) Close() {
+	s.mu.Lock()
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+	s.mu.Unlock()
+	s.Server.Close()
+}
+
+// Publish sends an event to every client listening, as if the items were
+// changed by someone else. An empty event ID gets the next number
+func (s *Server) Publish(event actions.StreamEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastEvent++
+	if event.ID == "" {
+		event.ID = strconv.Itoa(s.lastEvent)
+	}
+	s.history = append(s.history, event)
+	for events := range s.subscribers {
+		select {
+		case events <- event:
+		default:
+			// Too slow to keep up, like a real server the client gets
+			// dropped and reconnects
+			delete(s.subscribers, events)
+			close(events)
+		}
+	}
+}
+
+// DropStreams ends the connection of every client listening for events, as
+// a restarting server or a flaky network would
+func (s *Server) DropStreams() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for events := range s.subscribers {
+		delete(s.subscribers, events)
+		close(events)
+	}
+}
+
+// FailNext makes the next n requests fail with status, to try how the
+// client retries and when its circuit breaker opens
+func (s *Server) FailNext(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures, s.failStatus = n, status
+}
+
+// SetDelay makes the server wait for d before answering, to try timeouts
+// and canceled requests. The wait ends early when the client goes away
+func (s *Server) SetDelay(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delay = d
+}
+
+// HideTotal leaves the X-Total-Count header out of item lists, like APIs
+// that don't count their items
+func (s *Server) HideTotal(hide bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.noTotal = hide
+}
+
+// AssignIDs makes the server give created items IDs of its own, like APIs
+// that ignore the ID the client sends
+func (s *Server) AssignIDs(assign bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.assignIDs = assign
+}
+
+// Requests returns how many requests the server got, event streams
+// included
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// Source returns the store behind the server, to inspect or change items
+// directly
+func (s *Server) Source() *actions.MockSource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.source
+}
+
+// inject counts the requests, and delays or fails them as asked
+func (s *Server) inject(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests++
+		delay := s.delay
+		fail := s.failures > 0
+		if fail {
+			s.failures--
+		}
+		status := s.failStatus
+		s.mu.Unlock()
+
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if fail {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.APIKey != "" && r.Header.Get("Authorization") != "Bearer "+s.APIKey {
+			http.Error(w, "invalid API key", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) listItems(w http.ResponseWriter, r *http.Request) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	list, err := s.Source().List(r.Context(), actions.Page{Offset: offset, Limit: limit})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.mu.Lock()
+	noTotal := s.noTotal
+	s.mu.Unlock()
+	if !noTotal {
+		w.Header().Set("X-Total-Count", strconv.Itoa(list.Total))
+	}
+	writeJSON(w, http.StatusOK, list.Items)
+}
+
+func (s *Server) getItem(w http.ResponseWriter, r *http.Request) {
+	item, err := s.Source().Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
+
+func (s *Server) createItem(w http.ResponseWriter, r *http.Request) {
+	var item actions.DataItem
+	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	if s.assignIDs {
+		s.createdIDs++
+		item.ID = "server-" + strconv.Itoa(s.createdIDs)
+	}
+	s.mu.Unlock()
+	item, err := s.Source().Create(r.Context(), item)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.Publish(actions.StreamEvent{Type: actions.EventCreated, Item: item})
+	writeJSON(w, http.StatusCreated, item)
+}
+
+func (s *Server) updateItem(w http.ResponseWriter, r *http.Request) {
+	var item actions.DataItem
+	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	item.ID = r.PathValue("id")
+	item, err := s.Source().Update(r.Context(), item)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.Publish(actions.StreamEvent{Type: actions.EventUpdated, Item: item})
+	writeJSON(w, http.StatusOK, item)
+}
+
+func (s *Server) deleteItem(w http.ResponseWriter, r *http.Request) {
+	if err := s.Source().Delete(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+	s.Publish(actions.StreamEvent{Type: actions.EventDeleted, Item: actions.DataItem{ID: r.PathValue("id")}})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// missed returns the events published after the one with ID lastID, none
+// when the ID is empty or unknown. The caller holds the lock
+func (s *Server) missed(lastID string) []actions.StreamEvent {
+	if lastID == "" {
+		return nil
+	}
+	for i, event := range s.history {
+		if event.ID == lastID {
+			return slices.Clone(s.history[i+1:])
+		}
+	}
+	return nil
+}
+
+// writeEvent writes event in the Server-Sent Events format
+func writeEvent(w io.Writer, event actions.StreamEvent) {
+	data, _ := json.Marshal(event.Item)
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+}
+
+// streamEvents sends the changes to the items as Server-Sent Events until
+// the client goes away or the server is closed
+func (s *Server) streamEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	events := make(chan actions.StreamEvent, 64)
+	s.mu.Lock()
+	s.subscribers[events] = true
+	missed := s.missed(r.Header.Get("Last-Event-ID"))
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.subscribers[events] {
+			delete(s.subscribers, events)
+			close(events)
+		}
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	for _, event := range missed {
+		writeEvent(w, event)
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			writeEvent(w, event)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, actions.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
@@ -0,0 +1,89 @@
+// app/actions/store.go
+package actions
+
+import (
+	"bubbletea-app/app/config"
+	"io"
+	"sync"
+)
+
+// Store holds the DataSource selected in Settings. Pages share one store, so
+// they all see the same items, even with the in-memory mock backend, and
+// share one undo history
+type Store struct {
+	History *History
+
+	mu       sync.Mutex
+	settings config.Settings
+	source   DataSource
+	err      error
+}
+
+// NewStore opens the backend selected in settings
+func NewStore(settings config.Settings) *Store {
+	s := &Store{History: NewHistory(DefaultHistoryLimit)}
+	s.open(settings)
+	return s
+}
+
+// Reopen switches to the backend in settings. The backend stays open when
+// settings still point at it, so every page can call it on
+// SettingsChangedMsg
+func (s *Store) Reopen(settings config.Settings) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if settings.SameBackend(s.settings) && s.source != nil {
+		s.settings = settings
+		return
+	}
+	s.close()
+	s.open(settings)
+	// Changes made to the old backend can't be undone on the new one
+	s.History.Clear()
+}
+
+// Settings returns the settings the store was opened with
+func (s *Store) Settings() config.Settings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.settings
+}
+
+// Source returns the open backend, or the error that kept it from opening
+func (s *Store) Source() (DataSource, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.source, s.err
+}
+
+// Health reports retries and the circuit breaker of the backend. Local
+// backends have neither, so they are always healthy
+func (s *Store) Health() Health {
+	source, _ := s.Source()
+	if client, ok := source.(*Client); ok {
+		return client.Health()
+	}
+	return Health{}
+}
+
+// Close releases the backend
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.close()
+}
+
+func (s *Store) open(settings config.Settings) {
+	s.settings = settings
+	s.source, s.err = NewDataSource(settings)
+}
+
+func (s *Store) close() error {
+	closer, ok := s.source.(io.Closer)
+	s.source = nil
+	if !ok {
+		return nil
+	}
+	return closer.Close()
+}
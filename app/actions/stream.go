@@ -0,0 +1,267 @@
+// app/actions/stream.go
+package actions
+
+import (
+	"bubbletea-app/app/config"
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// StreamPath is where the API streams changes to its items as Server-Sent
+// Events
+const StreamPath = "/items/events"
+
+// Types of the events on the stream. Deleted events only carry the ID
+const (
+	EventCreated = "created"
+	EventUpdated = "updated"
+	EventDeleted = "deleted"
+)
+
+// StreamRetryPolicy spaces out reconnects. MaxAttempts doesn't apply, the
+// stream keeps trying until it is closed
+var StreamRetryPolicy = RetryPolicy{
+	BaseDelay: time.Second,
+	MaxDelay:  30 * time.Second,
+	Jitter:    0.5,
+}
+
+// StreamEvent is a change to the items, made by anyone using the API
+type StreamEvent struct {
+	// ID lets a reconnect resume after the last event seen
+	ID   string
+	Type string
+	Item DataItem
+}
+
+// StreamState tells how the connection to the stream is doing
+type StreamState int
+
+const (
+	// StreamOff means the backend has no stream, only the API does
+	StreamOff StreamState = iota
+	StreamConnecting
+	StreamLive
+	// StreamReconnecting waits a little before trying again after the
+	// connection failed or dropped
+	StreamReconnecting
+)
+
+func (s StreamState) String() string {
+	switch s {
+	case StreamConnecting:
+		return "Connecting…"
+	case StreamLive:
+		return "Live"
+	case StreamReconnecting:
+		return "Reconnecting…"
+	}
+	return "Off"
+}
+
+// StreamEventMsg carries an event that came in on the stream
+type StreamEventMsg struct {
+	Event  StreamEvent
+	stream *Stream
+}
+
+// StreamStatusMsg reports that the connection changed state. Err tells why
+// a reconnect is needed
+type StreamStatusMsg struct {
+	State  StreamState
+	Err    error
+	stream *Stream
+}
+
+// Stream subscribes to the item changes of the API and hands them out as
+// messages, one per Listen. It connects on the first Listen and reconnects
+// whenever the connection fails, until Close is called. A nil Stream is one
+// for a backend without events and never sends anything
+type Stream struct {
+	URL      string
+	APIKey   string
+	Retry    RetryPolicy
+	HTTP     *http.Client
+	settings config.Settings
+
+	start  sync.Once
+	ctx    context.Context
+	cancel context.CancelFunc
+	msgs   chan tea.Msg
+	lastID string
+}
+
+// NewStream creates the stream of the API configured in settings, or nil
+// when settings select another backend or no host
+func NewStream(settings config.Settings) *Stream {
+	if settings.DataBackend() != config.BackendREST || BaseURL(settings) == "" {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Stream{
+		URL:      BaseURL(settings) + StreamPath,
+		APIKey:   settings.APIKey,
+		Retry:    StreamRetryPolicy,
+		HTTP:     http.DefaultClient,
+		settings: settings,
+		ctx:      ctx,
+		cancel:   cancel,
+		msgs:     make(chan tea.Msg, 16),
+	}
+}
+
+// Listen returns the Cmd that waits for the next message of the stream.
+// Call it again after each message for which From reports true
+func (s *Stream) Listen() tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	s.start.Do(func() {
+		go s.run()
+	})
+	return func() tea.Msg {
+		// A closed stream ends the subscription without a message
+		msg, ok := <-s.msgs
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// From reports whether msg was sent by s, rather than by a stream closed
+// since
+func (s *Stream) From(msg tea.Msg) bool {
+	switch msg := msg.(type) {
+	case StreamEventMsg:
+		return s != nil && msg.stream == s
+	case StreamStatusMsg:
+		return s != nil && msg.stream == s
+	}
+	return false
+}
+
+// Close disconnects and stops reconnecting
+func (s *Stream) Close() {
+	if s != nil {
+		s.cancel()
+	}
+}
+
+// run keeps a connection open until the stream is closed. Each failed try
+// waits longer before the next, a connection that was live starts over
+func (s *Stream) run() {
+	defer close(s.msgs)
+
+	s.send(StreamStatusMsg{State: StreamConnecting, stream: s})
+	for retry := 1; ; retry++ {
+		live, err := s.connect()
+		if s.ctx.Err() != nil {
+			return
+		}
+		if live {
+			retry = 1
+		}
+		s.send(StreamStatusMsg{State: StreamReconnecting, Err: err, stream: s})
+		if sleep(s.ctx, s.Retry.Backoff(retry)) != nil {
+			return
+		}
+	}
+}
+
+// connect opens the stream and reads events until it ends. It reports
+// whether the connection went live
+func (s *Stream) connect() (bool, error) {
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if s.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.APIKey)
+	}
+	if s.lastID != "" {
+		req.Header.Set("Last-Event-ID", s.lastID)
+	}
+
+	resp, err := s.HTTP.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return false, ErrUnauthorized
+	case resp.StatusCode != http.StatusOK:
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return false, &APIError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(data))}
+	}
+
+	s.send(StreamStatusMsg{State: StreamLive, stream: s})
+	err = readEvents(resp.Body, func(event StreamEvent) {
+		s.lastID = event.ID
+		// Cached pages miss the change now
+		expireCache(s.settings)
+		s.send(StreamEventMsg{Event: event, stream: s})
+	})
+	if err == nil {
+		err = io.ErrUnexpectedEOF
+	}
+	return true, err
+}
+
+// send hands msg to Listen, unless the stream is closed meanwhile
+func (s *Stream) send(msg tea.Msg) {
+	select {
+	case s.msgs <- msg:
+	case <-s.ctx.Done():
+	}
+}
+
+// readEvents parses Server-Sent Events from r and calls handle for each
+// event of a known type. Comments, as sent to keep the connection open, and
+// unknown fields are skipped
+func readEvents(r io.Reader, handle func(StreamEvent)) error {
+	scanner := bufio.NewScanner(r)
+	var event StreamEvent
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if len(data) > 0 {
+				if err := json.Unmarshal([]byte(strings.Join(data, "\n")), &event.Item); err != nil {
+					return fmt.Errorf("decoding %s event: %w", event.Type, err)
+				}
+				switch event.Type {
+				case EventCreated, EventUpdated, EventDeleted:
+					handle(event)
+				}
+			}
+			event, data = StreamEvent{ID: event.ID}, nil
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event.Type = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			event.ID = value
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,206 @@
+// app/actions/stream_test.go
+package actions_test
+
+import (
+	"bubbletea-app/app/actions"
+	"bubbletea-app/app/actions/standin"
+	"bubbletea-app/app/config"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// quickReconnect spaces out reconnects like the stream does, only fast
+// enough for tests
+var quickReconnect = actions.RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 40 * time.Millisecond}
+
+// newStream subscribes to the events of server
+func newStream(t *testing.T, server *standin.Server) *actions.Stream {
+	t.Helper()
+	stream := actions.NewStream(server.Settings())
+	if stream == nil {
+		t.Fatal("no stream for the stand-in")
+	}
+	stream.Retry = quickReconnect
+	t.Cleanup(stream.Close)
+	return stream
+}
+
+// next waits for the next message of stream
+func next(t *testing.T, stream *actions.Stream) tea.Msg {
+	t.Helper()
+	msgs := make(chan tea.Msg, 1)
+	go func() { msgs <- stream.Listen()() }()
+	select {
+	case msg := <-msgs:
+		if !stream.From(msg) {
+			t.Fatalf("got %#v, not a message of the stream", msg)
+		}
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("the stream sent nothing")
+	}
+	return nil
+}
+
+// nextStatus waits for the next status of stream
+func nextStatus(t *testing.T, stream *actions.Stream) actions.StreamStatusMsg {
+	t.Helper()
+	msg, ok := next(t, stream).(actions.StreamStatusMsg)
+	if !ok {
+		t.Fatalf("got %#v, want a status", msg)
+	}
+	return msg
+}
+
+// nextEvent waits for the next event of stream
+func nextEvent(t *testing.T, stream *actions.Stream) actions.StreamEvent {
+	t.Helper()
+	msg, ok := next(t, stream).(actions.StreamEventMsg)
+	if !ok {
+		t.Fatalf("got %#v, want an event", msg)
+	}
+	return msg.Event
+}
+
+// connect waits until stream is live
+func connect(t *testing.T, stream *actions.Stream) {
+	t.Helper()
+	if status := nextStatus(t, stream); status.State != actions.StreamConnecting {
+		t.Fatalf("first status is %s", status.State)
+	}
+	if status := nextStatus(t, stream); status.State != actions.StreamLive {
+		t.Fatalf("got %s (%v), want live", status.State, status.Err)
+	}
+}
+
+func TestStreamOff(t *testing.T) {
+	settings := newServer(t, "").Settings()
+	settings.Backend = config.BackendMock
+	stream := actions.NewStream(settings)
+	if stream != nil {
+		t.Fatal("got a stream for the mock backend")
+	}
+	if stream.Listen() != nil || stream.From(actions.StreamStatusMsg{}) {
+		t.Error("a nil stream sends messages")
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	server := newServer(t, "secret")
+	stream := newStream(t, server)
+	connect(t, stream)
+
+	sent := []actions.StreamEvent{
+		{Type: actions.EventCreated, Item: actions.DataItem{ID: "4", Title: "Order flowers", Status: actions.StatusTodo}},
+		{Type: actions.EventUpdated, Item: actions.DataItem{ID: "2", Title: "Book the venue", Status: actions.StatusDone}},
+		{Type: actions.EventDeleted, Item: actions.DataItem{ID: "3"}},
+	}
+	for _, event := range sent {
+		server.Publish(event)
+	}
+	for i, want := range sent {
+		got := nextEvent(t, stream)
+		if got.ID == "" || got.Type != want.Type || !got.Item.Equal(want.Item) {
+			t.Errorf("event %d is %+v, want %+v", i, got, want)
+		}
+	}
+}
+
+func TestStreamResumes(t *testing.T) {
+	server := newServer(t, "")
+	stream := newStream(t, server)
+	connect(t, stream)
+
+	server.Publish(actions.StreamEvent{Type: actions.EventCreated, Item: actions.DataItem{ID: "4", Title: "Order flowers"}})
+	if event := nextEvent(t, stream); event.Item.ID != "4" {
+		t.Fatalf("got %+v", event)
+	}
+
+	// Events published while the stream is down come once it is back
+	server.DropStreams()
+	server.Publish(actions.StreamEvent{Type: actions.EventUpdated, Item: actions.DataItem{ID: "4", Title: "Order tulips"}})
+	server.Publish(actions.StreamEvent{Type: actions.EventDeleted, Item: actions.DataItem{ID: "1"}})
+
+	status := nextStatus(t, stream)
+	if status.State != actions.StreamReconnecting || status.Err == nil {
+		t.Fatalf("got %s (%v), want reconnecting with the reason", status.State, status.Err)
+	}
+	if status := nextStatus(t, stream); status.State != actions.StreamLive {
+		t.Fatalf("got %s (%v), want live again", status.State, status.Err)
+	}
+	if event := nextEvent(t, stream); event.Type != actions.EventUpdated || event.Item.Title != "Order tulips" {
+		t.Errorf("first missed event is %+v", event)
+	}
+	if event := nextEvent(t, stream); event.Type != actions.EventDeleted || event.Item.ID != "1" {
+		t.Errorf("second missed event is %+v", event)
+	}
+
+	// And the stream stays live
+	server.Publish(actions.StreamEvent{Type: actions.EventDeleted, Item: actions.DataItem{ID: "2"}})
+	if event := nextEvent(t, stream); event.Item.ID != "2" {
+		t.Errorf("got %+v after resuming", event)
+	}
+}
+
+func TestStreamBacksOff(t *testing.T) {
+	server := newServer(t, "")
+	server.FailNext(3, http.StatusServiceUnavailable)
+	stream := newStream(t, server)
+
+	start := time.Now()
+	if status := nextStatus(t, stream); status.State != actions.StreamConnecting {
+		t.Fatalf("first status is %s", status.State)
+	}
+	for range 3 {
+		status := nextStatus(t, stream)
+		var apiErr *actions.APIError
+		if status.State != actions.StreamReconnecting || !errors.As(status.Err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("got %s (%v), want reconnecting after a 503", status.State, status.Err)
+		}
+	}
+	if status := nextStatus(t, stream); status.State != actions.StreamLive {
+		t.Fatalf("got %s (%v), want live", status.State, status.Err)
+	}
+	// Waits of 10ms, 20ms and 40ms, the jitter is off
+	if elapsed := time.Since(start); elapsed < 70*time.Millisecond {
+		t.Errorf("connected after %s, want the reconnects spaced out", elapsed)
+	}
+	if got := server.Requests(); got != 4 {
+		t.Errorf("sent %d requests, want 4", got)
+	}
+}
+
+func TestStreamUnauthorized(t *testing.T) {
+	server := newServer(t, "secret")
+	settings := server.Settings()
+	settings.APIKey = "wrong"
+	stream := actions.NewStream(settings)
+	stream.Retry = quickReconnect
+	t.Cleanup(stream.Close)
+
+	nextStatus(t, stream)
+	if status := nextStatus(t, stream); status.State != actions.StreamReconnecting || !errors.Is(status.Err, actions.ErrUnauthorized) {
+		t.Errorf("got %s (%v), want ErrUnauthorized", status.State, status.Err)
+	}
+}
+
+func TestStreamClose(t *testing.T) {
+	stream := newStream(t, newServer(t, ""))
+	connect(t, stream)
+
+	stream.Close()
+	done := make(chan tea.Msg)
+	go func() { done <- stream.Listen()() }()
+	select {
+	case msg := <-done:
+		if msg != nil {
+			t.Errorf("got %#v after closing", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("Listen still waits after closing")
+	}
+}
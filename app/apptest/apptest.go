@@ -0,0 +1,279 @@
+// app/apptest/apptest.go
+
+// Package apptest drives models headlessly for tests. A Harness sends a
+// model key presses and window sizes, runs the commands it returns and
+// renders it as plain text, which golden files in testdata keep track of.
+// Run `go test ./... -update` to write the golden files anew
+package apptest
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+// DefaultWait is how long a Harness waits for a command. Commands that take
+// longer, like ticks for cursors, spinners and notifications, are dropped
+const DefaultWait = 50 * time.Millisecond
+
+// maxMessages is how many messages a single step may cause before the
+// Harness gives up on a model that keeps sending itself messages
+const maxMessages = 1000
+
+// Components mark their clickable areas as soon as they are created, which
+// needs the global zone manager
+func init() {
+	zone.NewGlobal()
+}
+
+// Harness holds a model under test
+type Harness struct {
+	// Wait is how long to wait for each command
+	Wait time.Duration
+
+	t        testing.TB
+	model    tea.Model
+	masks    []mask
+	quitting bool
+	hold     func(tea.Msg) bool
+	held     []tea.Msg
+}
+
+// mask replaces the parts of a view that change from run to run
+type mask struct {
+	pattern *regexp.Regexp
+	with    string
+}
+
+// New starts model at width x height: it runs Init and sends the size
+func New(t testing.TB, model tea.Model, width, height int) *Harness {
+	t.Helper()
+	h := &Harness{Wait: DefaultWait, t: t, model: model}
+	h.run(model.Init())
+	return h.Resize(width, height)
+}
+
+// Model returns the model as it is now
+func (h *Harness) Model() tea.Model {
+	return h.model
+}
+
+// Quitting reports whether the model asked to quit
+func (h *Harness) Quitting() bool {
+	return h.quitting
+}
+
+// Send delivers msgs to the model one after the other, each with whatever
+// the commands it returns lead to
+func (h *Harness) Send(msgs ...tea.Msg) *Harness {
+	h.t.Helper()
+	for _, msg := range msgs {
+		h.deliver(msg)
+	}
+	return h
+}
+
+// Hold keeps the messages for which match reports true from the model until
+// Release, to have them arrive after others, like a slow answer would
+func (h *Harness) Hold(match func(tea.Msg) bool) *Harness {
+	h.hold = match
+	return h
+}
+
+// Release stops holding messages and delivers the ones held
+func (h *Harness) Release() *Harness {
+	h.t.Helper()
+	held := h.held
+	h.hold, h.held = nil, nil
+	return h.Send(held...)
+}
+
+// Resize sends the model a new window size
+func (h *Harness) Resize(width, height int) *Harness {
+	h.t.Helper()
+	return h.Send(tea.WindowSizeMsg{Width: width, Height: height})
+}
+
+// Type sends a key press for every character of text
+func (h *Harness) Type(text string) *Harness {
+	h.t.Helper()
+	for _, r := range text {
+		h.Send(runeKey(r))
+	}
+	return h
+}
+
+// Press sends the keys named like bubbletea names them, as in "enter",
+// "ctrl+u", "shift+tab", "alt+x", "space" or "j"
+func (h *Harness) Press(names ...string) *Harness {
+	h.t.Helper()
+	for _, name := range names {
+		msg, ok := Key(name)
+		if !ok {
+			h.t.Fatalf("unknown key %q", name)
+		}
+		h.Send(msg)
+	}
+	return h
+}
+
+// Mask replaces what matches pattern in every view with with, for parts
+// like clocks and temporary paths that change from run to run
+func (h *Harness) Mask(pattern, with string) *Harness {
+	h.masks = append(h.masks, mask{pattern: regexp.MustCompile(pattern), with: with})
+	return h
+}
+
+// View renders the model as plain text, see Normalize, with the masks
+// applied
+func (h *Harness) View() string {
+	view := Normalize(h.model.View())
+	for _, m := range h.masks {
+		view = m.pattern.ReplaceAllString(view, m.with)
+	}
+	return view
+}
+
+// Golden compares the view to the golden file testdata/name.golden
+func (h *Harness) Golden(name string) *Harness {
+	h.t.Helper()
+	AssertGolden(h.t, name, h.View())
+	return h
+}
+
+// deliver updates the model with msg and runs the commands that follow
+func (h *Harness) deliver(msg tea.Msg) {
+	h.t.Helper()
+	queue := []tea.Msg{msg}
+	for n := 0; len(queue) > 0; n++ {
+		if n == maxMessages {
+			h.t.Fatalf("the model is still busy after %d messages", maxMessages)
+		}
+		msg, queue = queue[0], queue[1:]
+
+		// Batches and sequences run their commands in order here
+		if cmds, ok := commands(msg); ok {
+			for _, cmd := range cmds {
+				if next, ok := h.exec(cmd); ok {
+					queue = append(queue, next)
+				}
+			}
+			continue
+		}
+		if _, ok := msg.(tea.QuitMsg); ok {
+			h.quitting = true
+			continue
+		}
+		if h.hold != nil && h.hold(msg) {
+			h.held = append(h.held, msg)
+			continue
+		}
+
+		var cmd tea.Cmd
+		h.model, cmd = h.model.Update(msg)
+		if next, ok := h.exec(cmd); ok {
+			queue = append(queue, next)
+		}
+	}
+}
+
+// run delivers the message cmd produces, if any
+func (h *Harness) run(cmd tea.Cmd) {
+	h.t.Helper()
+	if msg, ok := h.exec(cmd); ok {
+		h.deliver(msg)
+	}
+}
+
+// exec runs cmd and returns its message, unless there is none or it takes
+// longer than Wait
+func (h *Harness) exec(cmd tea.Cmd) (tea.Msg, bool) {
+	if cmd == nil {
+		return nil, false
+	}
+	done := make(chan tea.Msg, 1)
+	go func() {
+		done <- cmd()
+	}()
+	select {
+	case msg := <-done:
+		return msg, msg != nil
+	case <-time.After(h.Wait):
+		return nil, false
+	}
+}
+
+// commands returns the commands in a batch or sequence message. Sequences
+// have no exported type, but are a list of commands all the same
+func commands(msg tea.Msg) ([]tea.Cmd, bool) {
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		return batch, true
+	}
+	v := reflect.ValueOf(msg)
+	if v.Kind() == reflect.Slice && v.Type().Elem() == reflect.TypeOf(tea.Cmd(nil)) {
+		cmds := make([]tea.Cmd, v.Len())
+		for i := range cmds {
+			cmds[i] = v.Index(i).Interface().(tea.Cmd)
+		}
+		return cmds, true
+	}
+	return nil, false
+}
+
+// keyTypes maps the names bubbletea gives keys to their types
+var keyTypes = func() map[string]tea.KeyType {
+	types := map[string]tea.KeyType{}
+	// Key types are small numbers, the runes and the extended keys below
+	// zero
+	for i := -200; i < 200; i++ {
+		if name := tea.KeyType(i).String(); name != "" {
+			types[name] = tea.KeyType(i)
+		}
+	}
+	return types
+}()
+
+// Key returns the key press named name, as in Press
+func Key(name string) (tea.KeyMsg, bool) {
+	var alt bool
+	if rest, ok := strings.CutPrefix(name, "alt+"); ok && rest != "" {
+		alt, name = true, rest
+	}
+	if name == "space" {
+		name = " "
+	}
+	if keyType, ok := keyTypes[name]; ok && keyType != tea.KeyRunes {
+		return tea.KeyMsg{Type: keyType, Alt: alt}, true
+	}
+	if runes := []rune(name); len(runes) == 1 {
+		msg := runeKey(runes[0])
+		msg.Alt = alt
+		return msg, true
+	}
+	return tea.KeyMsg{}, false
+}
+
+// runeKey returns the key press that types r
+func runeKey(r rune) tea.KeyMsg {
+	if r == ' ' {
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{r}}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+// Normalize turns a view into plain text that compares well: without colors
+// and other escape sequences, including zone marks, and without spaces at
+// the ends of lines
+func Normalize(view string) string {
+	lines := strings.Split(ansi.Strip(view), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,68 @@
+// app/apptest/golden.go
+package apptest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// update rewrites the golden files instead of comparing against them
+var update = flag.Bool("update", false, "write the golden files in testdata anew")
+
+// testdata is the testdata folder of the package under test. Tests start
+// in the package folder, but may change to another one
+var testdata = func() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "testdata"
+	}
+	return filepath.Join(wd, "testdata")
+}()
+
+// AssertGolden compares got to the golden file testdata/name.golden, or
+// writes it there when the tests run with -update
+func AssertGolden(t testing.TB, name, got string) {
+	t.Helper()
+	path := filepath.Join(testdata, name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file: %v (run the tests with -update to write it)", err)
+	}
+	if string(want) != got {
+		t.Errorf("%s doesn't match %s:\n%s", name, path, diff(string(want), got))
+	}
+}
+
+// diff lists the lines that differ between want and got
+func diff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	var b strings.Builder
+	for i := range max(len(wantLines), len(gotLines)) {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			fmt.Fprintf(&b, "line %d:\n- %s\n+ %s\n", i+1, w, g)
+		}
+	}
+	return b.String()
+}
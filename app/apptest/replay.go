@@ -0,0 +1,62 @@
+// app/apptest/replay.go
+package apptest
+
+import (
+	"bubbletea-app/app/debug"
+	"fmt"
+)
+
+// Replay sends the model the inputs of a recording made with debug.Model:
+// key presses, window sizes and mouse events, the rest follows from them.
+// Window sizes the app sends itself are among them, which does no harm.
+// When the model takes snapshots, the page, input focus, modal and help after
+// each input have to be what they were when the next input was recorded
+func (h *Harness) Replay(recording debug.Recording) *Harness {
+	h.t.Helper()
+	inputs := recording.Inputs()
+	_, check := h.model.(debug.Snapshotter)
+	for i, index := range inputs {
+		record := recording.Records[index]
+		h.Send(record.Input.Msg())
+
+		if !check {
+			continue
+		}
+		// Whatever the input led to was recorded before the next one
+		last := len(recording.Records) - 1
+		if i+1 < len(inputs) {
+			last = inputs[i+1] - 1
+		}
+		got := h.model.(debug.Snapshotter).Snapshot()
+		if diff := snapshotDiff(recording.Records[last].Snapshot, got); diff != "" {
+			h.t.Fatalf("input %d of %d, %s %s: %s", i+1, len(inputs), record.Type, record.Msg, diff)
+		}
+	}
+	return h
+}
+
+// snapshotDiff tells how got differs from want, leaving out the views that
+// clocks and spinners change
+func snapshotDiff(want, got debug.Snapshot) string {
+	switch {
+	case got.Page != want.Page:
+		return fmt.Sprintf("the page is %s, not %s", got.Page, want.Page)
+	case got.InputInFocus != want.InputInFocus:
+		return fmt.Sprintf("input focus is %t, not %t", got.InputInFocus, want.InputInFocus)
+	case got.ModalOpen != want.ModalOpen:
+		return fmt.Sprintf("modal open is %t, not %t", got.ModalOpen, want.ModalOpen)
+	case got.HelpOpen != want.HelpOpen:
+		return fmt.Sprintf("help open is %t, not %t", got.HelpOpen, want.HelpOpen)
+	}
+	return ""
+}
+
+// ReplayFile replays the recording in the file at path, see Replay
+func (h *Harness) ReplayFile(path string) *Harness {
+	h.t.Helper()
+	recording, err := debug.LoadRecording(path)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	return h.Replay(recording)
+}
@@ -0,0 +1,224 @@
+// app/apptest/tape.go
+package apptest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Tape is a script in a subset of the language of VHS tape files, see
+// https://github.com/charmbracelet/vhs. Key presses and Type are sent to the
+// model, Hide to Show sets up the terminal before the app runs and is
+// skipped, and the recording commands like Output, Set and Sleep do nothing.
+// Expect "text" checks the screen shows text and ExpectPage name that the
+// model's page is name. VHS doesn't know those two, so they may be written
+// as comments, as in `# Expect "Config saved"`
+type Tape struct {
+	Name     string
+	Commands []TapeCommand
+}
+
+// TapeCommand is a line of a tape
+type TapeCommand struct {
+	Line int
+	Name string
+	Args []string
+	// Count repeats key presses, as in `Down 2`
+	Count int
+}
+
+func (c TapeCommand) String() string {
+	if c.Name == "Type" || c.Name == "Expect" {
+		return c.Name + " " + strconv.Quote(c.Args[0])
+	}
+	return strings.TrimSpace(c.Name + " " + strings.Join(c.Args, " "))
+}
+
+// Paged is a model that can tell which page it shows, for ExpectPage
+type Paged interface {
+	Page() string
+}
+
+// tapeKeys maps the keys tapes press to the names bubbletea gives them
+var tapeKeys = map[string]string{
+	"Enter":     "enter",
+	"Tab":       "tab",
+	"Space":     "space",
+	"Backspace": "backspace",
+	"Delete":    "delete",
+	"Insert":    "insert",
+	"Escape":    "esc",
+	"Up":        "up",
+	"Down":      "down",
+	"Left":      "left",
+	"Right":     "right",
+	"PageUp":    "pgup",
+	"PageDown":  "pgdown",
+	"Home":      "home",
+	"End":       "end",
+}
+
+// ignoredCommands only matter to recordings
+var ignoredCommands = map[string]bool{
+	"Output": true, "Set": true, "Sleep": true, "Require": true, "Source": true, "Screenshot": true,
+}
+
+// ReadTape reads the tape file at path
+func ReadTape(path string) (Tape, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Tape{}, err
+	}
+	defer file.Close()
+	return ParseTape(path, file)
+}
+
+// ParseTape reads a tape named name from r. Lines between Hide and Show are
+// left out
+func ParseTape(name string, r io.Reader) (Tape, error) {
+	tape := Tape{Name: name}
+	hidden := false
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if rest, ok := strings.CutPrefix(text, "#"); ok {
+			// Only assertions count among the comments
+			text = strings.TrimSpace(rest)
+			if !strings.HasPrefix(text, "Expect") {
+				continue
+			}
+		}
+		if text == "" {
+			continue
+		}
+
+		fields, err := splitTapeLine(text)
+		if err != nil {
+			return tape, fmt.Errorf("%s:%d: %w", name, line, err)
+		}
+		command := TapeCommand{Line: line, Name: fields[0], Args: fields[1:], Count: 1}
+		// Speeds like Down@0.5 or Type@800ms only pace recordings
+		command.Name, _, _ = strings.Cut(command.Name, "@")
+
+		switch {
+		case command.Name == "Hide" || command.Name == "Show":
+			hidden = command.Name == "Hide"
+			continue
+		case hidden || ignoredCommands[command.Name]:
+			continue
+		}
+		if err := checkTapeCommand(&command); err != nil {
+			return tape, fmt.Errorf("%s:%d: %w", name, line, err)
+		}
+		tape.Commands = append(tape.Commands, command)
+	}
+	return tape, scanner.Err()
+}
+
+// checkTapeCommand makes sure the interpreter knows command, and reads the
+// count of key presses
+func checkTapeCommand(command *TapeCommand) error {
+	switch command.Name {
+	case "Type", "Expect", "ExpectPage":
+		if len(command.Args) != 1 {
+			return fmt.Errorf("%s takes one argument", command.Name)
+		}
+		return nil
+	}
+	if _, ok := tapeKey(command.Name); !ok {
+		return fmt.Errorf("unknown command %s", command.Name)
+	}
+	if len(command.Args) > 1 {
+		return fmt.Errorf("%s takes a count at most", command.Name)
+	}
+	if len(command.Args) == 1 {
+		count, err := strconv.Atoi(command.Args[0])
+		if err != nil || count < 1 {
+			return fmt.Errorf("%s: count %q is not a positive number", command.Name, command.Args[0])
+		}
+		command.Count = count
+	}
+	return nil
+}
+
+// tapeKey returns the bubbletea name of a key press command, like Enter,
+// Ctrl+U, Alt+X or Shift+Tab
+func tapeKey(name string) (string, bool) {
+	if key, ok := tapeKeys[name]; ok {
+		return key, true
+	}
+	modifier, rest, ok := strings.Cut(name, "+")
+	if !ok || rest == "" {
+		return "", false
+	}
+	if key, ok := tapeKeys[rest]; ok {
+		rest = key
+	} else if len([]rune(rest)) == 1 {
+		rest = strings.ToLower(rest)
+	}
+	key := strings.ToLower(modifier) + "+" + rest
+	if _, ok := Key(key); !ok {
+		return "", false
+	}
+	return key, true
+}
+
+// splitTapeLine splits a line into the command and its arguments. Strings
+// are quoted with ", ' or `
+func splitTapeLine(line string) ([]string, error) {
+	var fields []string
+	for line = strings.TrimSpace(line); line != ""; line = strings.TrimSpace(line) {
+		quote := line[0]
+		if quote != '"' && quote != '\'' && quote != '`' {
+			end := strings.IndexFunc(line, unicode.IsSpace)
+			if end < 0 {
+				end = len(line)
+			}
+			fields = append(fields, line[:end])
+			line = line[end:]
+			continue
+		}
+		end := strings.IndexByte(line[1:], quote)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated string %s", line)
+		}
+		fields = append(fields, line[1:end+1])
+		line = line[end+2:]
+	}
+	return fields, nil
+}
+
+// Play runs tape on the model. It stops at the first failed assertion
+func (h *Harness) Play(tape Tape) *Harness {
+	h.t.Helper()
+	for _, command := range tape.Commands {
+		where := fmt.Sprintf("%s:%d: %s", tape.Name, command.Line, command)
+		switch command.Name {
+		case "Type":
+			h.Type(command.Args[0])
+		case "Expect":
+			if view := h.View(); !strings.Contains(view, command.Args[0]) {
+				h.t.Fatalf("%s: not on the screen:\n%s", where, view)
+			}
+		case "ExpectPage":
+			paged, ok := h.model.(Paged)
+			if !ok {
+				h.t.Fatalf("%s: %T has no pages", where, h.model)
+			}
+			if page := paged.Page(); page != command.Args[0] {
+				h.t.Fatalf("%s: the page is %s", where, page)
+			}
+		default:
+			key, _ := tapeKey(command.Name)
+			for range command.Count {
+				h.Press(key)
+			}
+		}
+	}
+	return h
+}
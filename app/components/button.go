@@ -5,16 +5,19 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
 )
 
 // ButtonModel represents an interactive button
 type ButtonModel struct {
-	Text    string
-	OnClick func() tea.Msg
-	OnFocus func()
-	focused bool
-	keyMap  KeyMap
-	width   int
+	id       string
+	Text     string
+	OnClick  func() tea.Msg
+	OnFocus  func()
+	focused  bool
+	disabled bool
+	keyMap   KeyMap
+	width    int
 }
 
 // KeyMap defines button-specific keybindings
@@ -35,6 +38,7 @@ func DefaultKeyMap() KeyMap {
 // NewButtonModel creates a new button
 func NewButtonModel(text string, onClick func() tea.Msg) ButtonModel {
 	return ButtonModel{
+		id:      zone.NewPrefix(),
 		Text:    text,
 		OnClick: onClick,
 		keyMap:  DefaultKeyMap(),
@@ -70,9 +74,33 @@ func (b ButtonModel) IsFocused() bool {
 	return b.focused
 }
 
+// SetDisabled enables or disables the button
+func (b *ButtonModel) SetDisabled(disabled bool) {
+	b.disabled = disabled
+	if disabled {
+		b.focused = false
+	}
+}
+
+// IsDisabled returns whether the button is disabled
+func (b ButtonModel) IsDisabled() bool {
+	return b.disabled
+}
+
+// Clicked reports whether msg is a left click on the rendered button
+func (b ButtonModel) Clicked(msg tea.MouseMsg) bool {
+	return Clicked(b.id, msg)
+}
+
 // Update handles events for the button
 func (b ButtonModel) Update(msg tea.Msg) (ButtonModel, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		// Clicks work whether or not the button has keyboard focus
+		if !b.disabled && b.Clicked(msg) && b.OnClick != nil {
+			return b, func() tea.Msg { return b.OnClick() }
+		}
+
 	case tea.KeyMsg:
 		if !b.focused {
 			return b, nil
@@ -98,7 +126,11 @@ func (b ButtonModel) View() string {
 		Padding(0, 2).
 		Border(lipgloss.RoundedBorder())
 
-	if b.focused {
+	if b.disabled {
+		style = style.
+			BorderForeground(lipgloss.Color("#555555")).
+			Foreground(lipgloss.Color("#555555"))
+	} else if b.focused {
 		style = style.
 			BorderForeground(lipgloss.Color("#25A065")).
 			Bold(true)
@@ -107,5 +139,5 @@ func (b ButtonModel) View() string {
 			BorderForeground(lipgloss.Color("#AAAAAA"))
 	}
 
-	return style.Render(b.Text)
+	return zone.Mark(b.id, style.Render(b.Text))
 }
@@ -0,0 +1,96 @@
+// app/components/button_bar.go
+package components
+
+import (
+	"bubbletea-app/app/config"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ButtonBarModel is the row of buttons under a view that acts on what it
+// shows, like the item detail and the import preview. The buttons sit in a
+// row of the view's NavigationManager, enter presses the focused one and
+// back presses the one set with WithBack
+type ButtonBarModel struct {
+	buttons []ButtonModel
+	nav     *config.NavigationManager
+	keyMap  config.KeyMap
+	back    int
+}
+
+// NewButtonBarModel creates a button per label and adds them to row of nav.
+// Pressing a button sends onClick with its index
+func NewButtonBarModel(nav *config.NavigationManager, row int, keyMap config.KeyMap, onClick func(index int) tea.Msg, labels ...string) ButtonBarModel {
+	b := ButtonBarModel{
+		buttons: make([]ButtonModel, len(labels)),
+		nav:     nav,
+		keyMap:  keyMap,
+		back:    -1,
+	}
+	for i, label := range labels {
+		b.buttons[i] = NewButtonModel(label, func() tea.Msg { return onClick(i) })
+	}
+	// nav points into the slice, which every copy of the bar shares
+	for i := range b.buttons {
+		nav.AddItemAt(&b.buttons[i], row, i)
+	}
+	return b
+}
+
+// WithBack makes the back key press button index
+func (b ButtonBarModel) WithBack(index int) ButtonBarModel {
+	b.back = index
+	return b
+}
+
+// Focus moves the focus to button index
+func (b ButtonBarModel) Focus(index int) {
+	b.nav.FocusItem(&b.buttons[index])
+}
+
+// Press returns the Cmd of button index, as if it was clicked
+func (b ButtonBarModel) Press(index int) tea.Cmd {
+	return b.buttons[index].OnClick
+}
+
+// HandleKey presses the back button on the back key, and the focused
+// button on enter. It reports whether msg was one of them
+func (b ButtonBarModel) HandleKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch {
+	case b.back >= 0 && key.Matches(msg, b.keyMap.Back):
+		return b.Press(b.back), true
+	case key.Matches(msg, b.keyMap.Enter):
+		for i := range b.buttons {
+			if b.buttons[i].IsFocused() {
+				return b.Press(i), true
+			}
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+// HandleMouse focuses and presses the button msg clicks. It reports whether
+// msg was such a click
+func (b ButtonBarModel) HandleMouse(msg tea.MouseMsg) (tea.Cmd, bool) {
+	for i := range b.buttons {
+		if b.buttons[i].Clicked(msg) {
+			b.Focus(i)
+			var cmd tea.Cmd
+			b.buttons[i], cmd = b.buttons[i].Update(msg)
+			return cmd, true
+		}
+	}
+	return nil, false
+}
+
+// View renders the buttons side by side
+func (b ButtonBarModel) View() string {
+	views := make([]string, len(b.buttons))
+	for i, button := range b.buttons {
+		views[i] = button.View()
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, views...)
+}
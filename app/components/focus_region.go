@@ -0,0 +1,29 @@
+// app/components/focus_region.go
+package components
+
+// FocusRegion implements FocusableItem for an area the page renders itself,
+// like a list or a viewport. It only tracks whether the area has focus
+type FocusRegion struct {
+	Name    string
+	focused bool
+}
+
+// NewFocusRegion creates a new focus region
+func NewFocusRegion(name string) *FocusRegion {
+	return &FocusRegion{Name: name}
+}
+
+// Focus gives the region focus
+func (r *FocusRegion) Focus() {
+	r.focused = true
+}
+
+// Blur removes focus from the region
+func (r *FocusRegion) Blur() {
+	r.focused = false
+}
+
+// IsFocused returns whether the region is focused
+func (r *FocusRegion) IsFocused() bool {
+	return r.focused
+}
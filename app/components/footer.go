@@ -15,6 +15,6 @@ func (m FooterModel) View(width int) string {
 		Foreground(lipgloss.Color("#FFFDF5")).
 		Background(lipgloss.Color("#2F4858")).
 		Padding(0, 1).
-		Width(width - 2).
+		Width(width).
 		Render("Bubble Tea App Boilerplate • github.com/executionreverted/mango-bubbletea")
 }
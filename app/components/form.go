@@ -0,0 +1,191 @@
+// app/components/form.go
+package components
+
+import (
+	"bubbletea-app/app/config"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+// FormField describes one input of a form
+type FormField struct {
+	Label       string
+	Placeholder string
+	Value       string
+}
+
+// FormModel is a small stack of text inputs that is filled in and then
+// submitted or cancelled. Enter moves to the next input and submits on the
+// last one, Esc cancels
+type FormModel struct {
+	id       string
+	Title    string
+	labels   []string
+	inputs   []textinput.Model
+	focused  int
+	open     bool
+	err      error
+	keyMap   config.KeyMap
+	width    int
+	OnSubmit func(values []string) tea.Msg
+	OnCancel func() tea.Msg
+	// Validate may reject the values before OnSubmit runs. The error is
+	// shown in the form, which stays open
+	Validate func(values []string) error
+}
+
+// NewFormModel creates an open form with the first input focused
+func NewFormModel(title string, keyMap config.KeyMap, fields ...FormField) FormModel {
+	f := FormModel{
+		id:     zone.NewPrefix(),
+		Title:  title,
+		open:   true,
+		keyMap: keyMap,
+	}
+	for _, field := range fields {
+		input := textinput.New()
+		input.Placeholder = field.Placeholder
+		input.SetValue(field.Value)
+		input.KeyMap.Paste = keyMap.Paste
+		f.labels = append(f.labels, field.Label)
+		f.inputs = append(f.inputs, input)
+	}
+	f.focus(0)
+	return f
+}
+
+// IsOpen returns whether the form is still being filled in
+func (f FormModel) IsOpen() bool {
+	return f.open
+}
+
+// Values returns the trimmed input values in field order
+func (f FormModel) Values() []string {
+	values := make([]string, len(f.inputs))
+	for i, input := range f.inputs {
+		values[i] = strings.TrimSpace(input.Value())
+	}
+	return values
+}
+
+// SetWidth sets the width the form renders at, border included
+func (f *FormModel) SetWidth(width int) {
+	f.width = width
+	for i := range f.inputs {
+		// Leave room for the border, the padding and the prompt
+		f.inputs[i].Width = max(width-8, 1)
+	}
+}
+
+func (f FormModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (f FormModel) Update(msg tea.Msg) (FormModel, tea.Cmd) {
+	if !f.open {
+		return f, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, f.keyMap.Esc):
+			f.open = false
+			if f.OnCancel != nil {
+				return f, f.OnCancel
+			}
+			return f, nil
+		case key.Matches(msg, f.keyMap.Enter):
+			if f.focused < len(f.inputs)-1 {
+				f.focus(f.focused + 1)
+				return f, nil
+			}
+			return f.submit()
+		case key.Matches(msg, f.keyMap.Tab):
+			f.focus((f.focused + 1) % len(f.inputs))
+			return f, nil
+		case key.Matches(msg, f.keyMap.ShiftTab):
+			f.focus((f.focused - 1 + len(f.inputs)) % len(f.inputs))
+			return f, nil
+		}
+
+	case tea.MouseMsg:
+		for i := range f.inputs {
+			if Clicked(f.inputID(i), msg) {
+				f.focus(i)
+				return f, nil
+			}
+		}
+		return f, nil
+	}
+
+	var cmd tea.Cmd
+	f.inputs[f.focused], cmd = f.inputs[f.focused].Update(msg)
+	return f, cmd
+}
+
+// submit validates the values and closes the form if they are fine
+func (f FormModel) submit() (FormModel, tea.Cmd) {
+	values := f.Values()
+	if f.Validate != nil {
+		if f.err = f.Validate(values); f.err != nil {
+			return f, nil
+		}
+	}
+
+	f.open = false
+	if f.OnSubmit != nil {
+		return f, func() tea.Msg { return f.OnSubmit(values) }
+	}
+	return f, nil
+}
+
+func (f *FormModel) focus(index int) {
+	for i := range f.inputs {
+		f.inputs[i].Blur()
+	}
+	if len(f.inputs) > 0 {
+		f.focused = index
+		f.inputs[index].Focus()
+	}
+}
+
+func (f FormModel) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFFFFF")).Render(f.Title)
+	rows := []string{title, ""}
+
+	for i, input := range f.inputs {
+		labelColor := lipgloss.Color("#888888")
+		if i == f.focused {
+			labelColor = lipgloss.Color("#25A065")
+		}
+		label := lipgloss.NewStyle().Foreground(labelColor).Render(f.labels[i])
+		rows = append(rows, label, zone.Mark(f.inputID(i), input.View()))
+	}
+
+	if f.err != nil {
+		rows = append(rows, "", lipgloss.NewStyle().Foreground(lipgloss.Color("#F44336")).Render(f.err.Error()))
+	}
+	rows = append(rows, "", lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888")).
+		Render("enter: next/save • tab: switch field • esc: cancel"))
+
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#874BFD")).
+		Padding(0, 1)
+	if f.width > 0 {
+		style = style.Width(max(f.width-2, 0))
+	}
+	return style.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+func (f FormModel) inputID(index int) string {
+	return fmt.Sprintf("%sinput-%d", f.id, index)
+}
@@ -1,6 +1,7 @@
 package components
 
 import (
+	"bubbletea-app/app/layout"
 	"bubbletea-app/app/styles"
 )
 
@@ -15,5 +16,9 @@ func NewHeaderModel(title string) HeaderModel {
 }
 
 func (m HeaderModel) View(width int) string {
-	return styles.HeaderStyle.Width(width - 4).Render(m.title)
+	// Compact headers drop the vertical padding to leave room for content
+	if layout.Compact(width) {
+		return styles.HeaderStyle.Padding(0, 1).MarginBottom(0).Width(width).Render(m.title)
+	}
+	return styles.HeaderStyle.Width(width).Render(m.title)
 }
@@ -5,9 +5,12 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 )
 
-// InputWrapper wraps textinput.Model to implement FocusableItem
+// InputWrapper wraps textinput.Model to implement FocusableItem. Navigation
+// focus only selects the input; typing into it starts with Edit
 type InputWrapper struct {
-	Input *textinput.Model
+	Input    *textinput.Model
+	selected bool
+	disabled bool
 }
 
 // NewInputWrapper creates a new wrapper for textinput
@@ -17,17 +20,43 @@ func NewInputWrapper(input *textinput.Model) *InputWrapper {
 	}
 }
 
-// Focus sets focus on the input
+// Focus selects the input
 func (w *InputWrapper) Focus() {
-	w.Input.Focus()
+	w.selected = true
 }
 
-// Blur removes focus from the input
+// Blur deselects the input and stops editing it
 func (w *InputWrapper) Blur() {
+	w.selected = false
 	w.Input.Blur()
 }
 
-// IsFocused returns whether the input is focused
+// IsFocused returns whether the input is selected
 func (w *InputWrapper) IsFocused() bool {
+	return w.selected
+}
+
+// Edit focuses the underlying textinput so it receives key presses
+func (w *InputWrapper) Edit() {
+	w.Input.Focus()
+}
+
+// StopEditing blurs the underlying textinput but keeps it selected
+func (w *InputWrapper) StopEditing() {
+	w.Input.Blur()
+}
+
+// IsEditing returns whether the underlying textinput has focus
+func (w *InputWrapper) IsEditing() bool {
 	return w.Input.Focused()
 }
+
+// SetDisabled enables or disables the input for navigation
+func (w *InputWrapper) SetDisabled(disabled bool) {
+	w.disabled = disabled
+}
+
+// IsDisabled returns whether navigation should skip the input
+func (w *InputWrapper) IsDisabled() bool {
+	return w.disabled
+}
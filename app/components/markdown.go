@@ -0,0 +1,302 @@
+// app/components/markdown.go
+package components
+
+import (
+	"bubbletea-app/app/config"
+	"bubbletea-app/app/global"
+	"bubbletea-app/app/styles"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var findMatchStyle = lipgloss.NewStyle().Background(lipgloss.Color("#FFD700")).Foreground(lipgloss.Color("#000000"))
+
+// MarkdownModel renders Markdown into a scrollable viewport: headings,
+// lists, code blocks with syntax highlighting, tables and links, in colors
+// that suit the terminal background. StartFind searches the text, a bar
+// under it shows the query while there is one
+type MarkdownModel struct {
+	viewport viewport.Model
+	source   string
+	keyMap   config.KeyMap
+	height   int
+	// lines is the rendered text, before matches are highlighted
+	lines []string
+
+	find    textinput.Model
+	finding bool
+	query   string
+	// matches are the lines with the query in them, current the one
+	// scrolled to
+	matches []int
+	current int
+}
+
+// NewMarkdownModel creates a viewer for source. It renders once it knows
+// its size
+func NewMarkdownModel(source string, keyMap config.KeyMap) MarkdownModel {
+	find := textinput.New()
+	find.Prompt = "/"
+	find.Placeholder = "find in text"
+	find.KeyMap.Paste = keyMap.Paste
+	return MarkdownModel{
+		viewport: viewport.New(0, 0),
+		source:   source,
+		keyMap:   keyMap,
+		find:     find,
+	}
+}
+
+// SetContent replaces the Markdown and scrolls back to the top
+func (m *MarkdownModel) SetContent(source string) {
+	m.source = source
+	m.render()
+	m.viewport.GotoTop()
+}
+
+// SetSize sets the size of the viewport and wraps the text to its width
+func (m *MarkdownModel) SetSize(width, height int) {
+	m.viewport.Width = width
+	m.height = height
+	m.render()
+}
+
+// ScrollPercent returns how far down the content is scrolled, 0 to 1
+func (m MarkdownModel) ScrollPercent() float64 {
+	return m.viewport.ScrollPercent()
+}
+
+func (m *MarkdownModel) render() {
+	if m.viewport.Width <= 0 {
+		return
+	}
+	m.lines = strings.Split(RenderMarkdown(m.source, m.viewport.Width), "\n")
+	m.highlight()
+}
+
+// Finding reports whether the find input has focus
+func (m MarkdownModel) Finding() bool {
+	return m.finding
+}
+
+// StartFind focuses the find input. Keys go to it until Enter keeps the
+// matches highlighted or Esc clears them
+func (m MarkdownModel) StartFind() (MarkdownModel, tea.Cmd) {
+	if m.finding {
+		return m, nil
+	}
+	m.finding = true
+	m.find.SetValue(m.query)
+	m.find.CursorEnd()
+	m.highlight()
+	return m, tea.Batch(m.find.Focus(), func() tea.Msg {
+		return global.InputFocusChangedMsg(true)
+	})
+}
+
+// stopFind gives the keys back to the page
+func (m MarkdownModel) stopFind() (MarkdownModel, tea.Cmd) {
+	m.finding = false
+	m.find.Blur()
+	m.highlight()
+	return m, func() tea.Msg {
+		return global.InputFocusChangedMsg(false)
+	}
+}
+
+// updateFind handles keys while the find input has focus
+func (m MarkdownModel) updateFind(msg tea.KeyMsg) (MarkdownModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keyMap.Esc):
+		m.query = ""
+		return m.stopFind()
+	case key.Matches(msg, m.keyMap.Enter):
+		return m.stopFind()
+	}
+
+	var cmd tea.Cmd
+	m.find, cmd = m.find.Update(msg)
+	if query := strings.TrimSpace(m.find.Value()); query != m.query {
+		m.query = query
+		m.current = 0
+		m.highlight()
+		// Start at the first match from the top of the screen on
+		for i, line := range m.matches {
+			if line >= m.viewport.YOffset {
+				m.current = i
+				break
+			}
+		}
+		m.scrollToMatch()
+	}
+	return m, cmd
+}
+
+// nextMatch scrolls to the match step matches further, wrapping around
+func (m *MarkdownModel) nextMatch(step int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.current = (m.current + step + len(m.matches)) % len(m.matches)
+	m.scrollToMatch()
+}
+
+// scrollToMatch brings the current match into view, a few lines from the
+// top so what leads up to it shows as well
+func (m *MarkdownModel) scrollToMatch() {
+	if len(m.matches) == 0 {
+		return
+	}
+	line := m.matches[m.current]
+	if line < m.viewport.YOffset || line >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(line - min(2, m.viewport.Height/3))
+	}
+}
+
+// highlight marks the query in the text and finds the lines it is on. The
+// find bar takes a line from the viewport while it shows
+func (m *MarkdownModel) highlight() {
+	m.viewport.Height = m.height
+	if m.finding || m.query != "" {
+		m.viewport.Height = max(m.height-1, 0)
+	}
+
+	m.matches = m.matches[:0:0]
+	lines := m.lines
+	if m.query != "" {
+		lines = make([]string, len(m.lines))
+		for i, line := range m.lines {
+			lines[i] = line
+			plain := ansi.Strip(line)
+			if runes := findRunes(plain, m.query); len(runes) > 0 {
+				// The match is shown on the plain line, the colors of the
+				// rest of it would get in the way
+				lines[i] = lipgloss.StyleRunes(plain, runes, findMatchStyle, lipgloss.NewStyle())
+				m.matches = append(m.matches, i)
+			}
+		}
+	}
+	m.current = min(m.current, max(len(m.matches)-1, 0))
+	offset := m.viewport.YOffset
+	m.viewport.SetContent(strings.Join(lines, "\n"))
+	m.viewport.SetYOffset(offset)
+}
+
+// findRunes returns the rune indexes of every match of query in text,
+// ignoring case
+func findRunes(text, query string) []int {
+	lower, query := strings.ToLower(text), strings.ToLower(query)
+	// Lowercasing may change the length of some runes, the indexes would be
+	// off then
+	if len(lower) != len(text) {
+		return nil
+	}
+	var runes []int
+	for start := 0; ; {
+		i := strings.Index(lower[start:], query)
+		if i < 0 {
+			return runes
+		}
+		first := utf8.RuneCountInString(text[:start+i])
+		for r := range utf8.RuneCountInString(query) {
+			runes = append(runes, first+r)
+		}
+		start += i + len(query)
+	}
+}
+
+// RenderMarkdown renders source wrapped to width, or returns it unchanged
+// if it can't be rendered
+func RenderMarkdown(source string, width int) string {
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStyles(styles.MarkdownStyle(lipgloss.HasDarkBackground())),
+		// Leave room for the document margin
+		glamour.WithWordWrap(max(width-4, 10)),
+	)
+	if err != nil {
+		return source
+	}
+	out, err := renderer.Render(source)
+	if err != nil {
+		return source
+	}
+	return strings.TrimRight(out, "\n")
+}
+
+// Update scrolls with the up and down keys, page up and down, home and end
+// and the mouse wheel. n and N go to the next and previous match of the
+// query, Esc clears it
+func (m MarkdownModel) Update(msg tea.Msg) (MarkdownModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.finding {
+			return m.updateFind(msg)
+		}
+		if m.query != "" {
+			switch {
+			case key.Matches(msg, m.keyMap.Esc):
+				m.query = ""
+				m.highlight()
+				return m, nil
+			case msg.String() == "n":
+				m.nextMatch(1)
+				return m, nil
+			case msg.String() == "N":
+				m.nextMatch(-1)
+				return m, nil
+			}
+		}
+		switch {
+		case key.Matches(msg, m.keyMap.Up):
+			m.viewport.LineUp(1)
+		case key.Matches(msg, m.keyMap.Down):
+			m.viewport.LineDown(1)
+		}
+		switch msg.String() {
+		case "pgup":
+			m.viewport.ViewUp()
+		case "pgdown":
+			m.viewport.ViewDown()
+		case "home":
+			m.viewport.GotoTop()
+		case "end":
+			m.viewport.GotoBottom()
+		}
+		return m, nil
+
+	case tea.MouseMsg:
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m MarkdownModel) View() string {
+	if !m.finding && m.query == "" {
+		return m.viewport.View()
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, m.viewport.View(), m.findStatus())
+}
+
+// findStatus is the bar under the text, with the input while typing
+func (m MarkdownModel) findStatus() string {
+	count := "no matches"
+	if len(m.matches) > 0 {
+		count = fmt.Sprintf("%d of %d", m.current+1, len(m.matches))
+	}
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	if m.finding {
+		return m.find.View() + "  " + style.Render(count)
+	}
+	return style.Render(fmt.Sprintf("Find: %s • %s • n/N next/previous • esc to clear", m.query, count))
+}
@@ -2,26 +2,35 @@ package components
 
 import (
 	"bubbletea-app/app/config"
+	"bubbletea-app/app/layout"
 	"fmt"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
 )
 
 // ModalModel represents the configuration and state of a modal dialog
 type ModalModel struct {
+	id               string
 	title            string
 	description      string
 	isOpen           bool
 	onConfirm        func() tea.Cmd
 	onCancel         func() tea.Cmd
 	buttonFocusIndex int
+	// A prompt asks for a line of text, which confirming passes to onSubmit
+	prompt   bool
+	input    textinput.Model
+	onSubmit func(value string) tea.Cmd
 }
 
 // NewModal creates a new modal with specified configuration
 func NewModal(title, description string) ModalModel {
 	return ModalModel{
+		id:          zone.NewPrefix(),
 		title:       title,
 		description: description,
 		isOpen:      false,
@@ -41,6 +50,21 @@ func (m *ModalModel) Open(title string, description string, onConfirm func() tea
 	m.isOpen = true
 	m.onConfirm = onConfirm
 	m.onCancel = onCancel
+	m.prompt = false
+}
+
+// OpenPrompt shows the modal with an input filled in with value. Confirming
+// passes what was typed to onSubmit. The returned Cmd starts the cursor
+func (m *ModalModel) OpenPrompt(title, description, value string, onSubmit func(string) tea.Cmd, onCancel func() tea.Cmd) tea.Cmd {
+	m.Open(title, description, nil, onCancel)
+	m.prompt = true
+	m.onSubmit = onSubmit
+	m.buttonFocusIndex = 0
+	m.input = textinput.New()
+	m.input.Prompt = "> "
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	return m.input.Focus()
 }
 
 // Close hides the modal
@@ -50,6 +74,8 @@ func (m *ModalModel) Close() {
 	m.isOpen = false
 	m.onConfirm = nil
 	m.onCancel = nil
+	m.prompt = false
+	m.onSubmit = nil
 }
 
 // View renders the modal dialog
@@ -61,6 +87,14 @@ func (m ModalModel) View(width, height int) string {
 	modalWidth := width - 10
 	modalHeight := height / 2
 
+	// On compact terminals the modal takes the whole screen, less its border
+	if layout.Compact(width) {
+		modalWidth = width - 2
+		modalHeight = height - 2
+	}
+	modalWidth = max(modalWidth, 0)
+	modalHeight = max(modalHeight, 0)
+
 	modalStyle := lipgloss.NewStyle().
 		Width(modalWidth).
 		Height(modalHeight).
@@ -109,12 +143,20 @@ func (m ModalModel) View(width, height int) string {
 			}
 		}
 
-		renderedButtons[i] = buttonStyle.Render(button)
+		renderedButtons[i] = zone.Mark(m.buttonID(i), buttonStyle.Render(button))
+	}
+	description := descriptionStyle.Render(m.description)
+	if m.prompt {
+		// The input is as wide as the modal allows, less its padding and
+		// the prompt
+		input := m.input
+		input.Width = max(modalWidth-2-lipgloss.Width(input.Prompt)-1, 1)
+		description = lipgloss.JoinVertical(lipgloss.Left, description, "", input.View())
 	}
 	modalContent := fmt.Sprintf(
 		"%s\n\n%s\n\n%s",
 		titleStyle.Render(m.title),
-		descriptionStyle.Render(m.description),
+		description,
 		buttonsStyle.Render(
 			lipgloss.JoinHorizontal(
 				lipgloss.Center,
@@ -134,6 +176,18 @@ func (m *ModalModel) HandleKey(msg tea.KeyMsg, keyMap config.KeyMap) tea.Cmd {
 		return nil
 	}
 
+	// Prompts take every key but the ones that close them
+	if m.prompt && !key.Matches(msg, keyMap.Enter) && !key.Matches(msg, keyMap.Back) {
+		if key.Matches(msg, keyMap.Tab) {
+			m.buttonFocusIndex = 1 - m.buttonFocusIndex
+			return nil
+		}
+		m.input.KeyMap.Paste = keyMap.Paste
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return cmd
+	}
+
 	switch {
 	case key.Matches(msg, keyMap.Left) || msg.String() == "h":
 		m.buttonFocusIndex = 0
@@ -144,21 +198,7 @@ func (m *ModalModel) HandleKey(msg tea.KeyMsg, keyMap config.KeyMap) tea.Cmd {
 		return nil
 
 	case key.Matches(msg, keyMap.Enter):
-		m.isOpen = false
-
-		if m.buttonFocusIndex == 0 && m.onConfirm != nil {
-			// Store the command before closing the modal
-			cmd := m.onConfirm()
-			return cmd
-		}
-
-		if m.buttonFocusIndex == 1 && m.onCancel != nil {
-			// Store the command before closing the modal
-			cmd := m.onCancel()
-			return cmd
-		}
-
-		return nil
+		return m.choose(m.buttonFocusIndex)
 
 	case key.Matches(msg, keyMap.Back):
 		m.isOpen = false
@@ -170,3 +210,46 @@ func (m *ModalModel) HandleKey(msg tea.KeyMsg, keyMap config.KeyMap) tea.Cmd {
 
 	return nil
 }
+
+// HandleMouse confirms or cancels the modal when one of its buttons is clicked
+func (m *ModalModel) HandleMouse(msg tea.MouseMsg) tea.Cmd {
+	if !m.isOpen {
+		return nil
+	}
+
+	for i := 0; i < 2; i++ {
+		if Clicked(m.buttonID(i), msg) {
+			m.buttonFocusIndex = i
+			return m.choose(i)
+		}
+	}
+	return nil
+}
+
+// choose closes the modal and runs the handler of the button at index
+func (m *ModalModel) choose(index int) tea.Cmd {
+	m.isOpen = false
+
+	if index == 0 && m.prompt && m.onSubmit != nil {
+		return m.onSubmit(m.input.Value())
+	}
+
+	if index == 0 && m.onConfirm != nil {
+		// Store the command before closing the modal
+		cmd := m.onConfirm()
+		return cmd
+	}
+
+	if index == 1 && m.onCancel != nil {
+		// Store the command before closing the modal
+		cmd := m.onCancel()
+		return cmd
+	}
+
+	return nil
+}
+
+// buttonID returns the zone id of the button at index
+func (m ModalModel) buttonID(index int) string {
+	return fmt.Sprintf("%sbutton-%d", m.id, index)
+}
@@ -0,0 +1,98 @@
+// app/components/modal_test.go
+package components_test
+
+import (
+	"bubbletea-app/app/apptest"
+	"bubbletea-app/app/components"
+	"bubbletea-app/app/config"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// modalHost shows a modal the way the app does, and what it was closed with
+// below it
+type modalHost struct {
+	modal  components.ModalModel
+	keyMap config.KeyMap
+	result string
+	width  int
+	height int
+}
+
+// resultMsg is what the modal's handlers send
+type resultMsg string
+
+func newModalHost() modalHost {
+	return modalHost{modal: components.NewModal("", ""), keyMap: config.DefaultKeyMap()}
+}
+
+func result(text string) func() tea.Cmd {
+	return func() tea.Cmd {
+		return func() tea.Msg { return resultMsg(text) }
+	}
+}
+
+func (m modalHost) Init() tea.Cmd {
+	return nil
+}
+
+func (m modalHost) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		return m, m.modal.HandleKey(msg, m.keyMap)
+	case resultMsg:
+		m.result = string(msg)
+	}
+	return m, nil
+}
+
+func (m modalHost) View() string {
+	if !m.modal.IsOpen() {
+		return "Closed: " + m.result
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, m.modal.View(m.width, m.height-1), "Result: "+m.result)
+}
+
+func TestModalConfirm(t *testing.T) {
+	host := newModalHost()
+	host.modal.Open("Delete item?", "'Task 2' will be gone for good.", result("confirmed"), result("cancelled"))
+
+	h := apptest.New(t, host, 60, 20)
+	h.Golden("modal_confirm")
+	h.Press("right").Golden("modal_cancel_focused")
+	h.Press("left", "enter").Golden("modal_confirmed")
+}
+
+func TestModalCancel(t *testing.T) {
+	host := newModalHost()
+	host.modal.Open("Delete item?", "'Task 2' will be gone for good.", result("confirmed"), result("cancelled"))
+
+	apptest.New(t, host, 60, 20).Press("esc").Golden("modal_cancelled")
+}
+
+func TestModalPrompt(t *testing.T) {
+	host := newModalHost()
+	host.modal.OpenPrompt("Export 3 items", "Save them to a file", "items.json",
+		func(value string) tea.Cmd {
+			return func() tea.Msg { return resultMsg("submitted " + value) }
+		},
+		result("cancelled"),
+	)
+
+	h := apptest.New(t, host, 60, 20)
+	h.Golden("modal_prompt")
+	// Letters like h and l go to the input, not the buttons
+	h.Press("ctrl+u").Type("hello.csv").Golden("modal_prompt_typed")
+	h.Press("enter").Golden("modal_prompt_submitted")
+}
+
+func TestModalCompact(t *testing.T) {
+	host := newModalHost()
+	host.modal.Open("Really?", "Are you sure you want to quit?", result("confirmed"), nil)
+
+	apptest.New(t, host, 40, 14).Golden("modal_compact")
+}
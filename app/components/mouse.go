@@ -0,0 +1,15 @@
+// app/components/mouse.go
+package components
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+// Clicked reports whether msg is a left click released inside the zone id
+func Clicked(id string, msg tea.MouseMsg) bool {
+	if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+		return false
+	}
+	return zone.Get(id).InBounds(msg)
+}
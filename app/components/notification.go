@@ -0,0 +1,79 @@
+// app/components/notification.go
+package components
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// NotificationDuration is how long a notification stays up
+const NotificationDuration = 5 * time.Second
+
+// NotificationExpiredMsg hides the notification with the given id and
+// sequence number, unless a newer one replaced it meanwhile
+type NotificationExpiredMsg struct {
+	id  int64
+	seq int
+}
+
+// NotificationModel shows a short message that goes away by itself
+type NotificationModel struct {
+	id    int64
+	seq   int
+	text  string
+	isErr bool
+}
+
+// NewNotificationModel creates an empty notification
+func NewNotificationModel() NotificationModel {
+	return NotificationModel{id: timerIDs.Add(1)}
+}
+
+// Show displays text until NotificationDuration has passed. The returned
+// command must reach Update for the notification to go away
+func (n *NotificationModel) Show(text string) tea.Cmd {
+	return n.show(text, false)
+}
+
+// ShowError displays text as an error
+func (n *NotificationModel) ShowError(text string) tea.Cmd {
+	return n.show(text, true)
+}
+
+func (n *NotificationModel) show(text string, isErr bool) tea.Cmd {
+	n.seq++
+	n.text = text
+	n.isErr = isErr
+
+	msg := NotificationExpiredMsg{id: n.id, seq: n.seq}
+	return tea.Tick(NotificationDuration, func(time.Time) tea.Msg {
+		return msg
+	})
+}
+
+// Dismiss hides the notification right away
+func (n *NotificationModel) Dismiss() {
+	n.text = ""
+}
+
+// Visible returns whether there is something to show
+func (n NotificationModel) Visible() bool {
+	return n.text != ""
+}
+
+func (n NotificationModel) Update(msg tea.Msg) (NotificationModel, tea.Cmd) {
+	if msg, ok := msg.(NotificationExpiredMsg); ok && msg.id == n.id && msg.seq == n.seq {
+		n.text = ""
+	}
+	return n, nil
+}
+
+func (n NotificationModel) View() string {
+	color := lipgloss.Color("#25A065")
+	if n.isErr {
+		color = lipgloss.Color("#F44336")
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(n.text)
+}
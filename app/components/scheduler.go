@@ -0,0 +1,79 @@
+// app/components/scheduler.go
+package components
+
+import (
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PausedRetryInterval is how soon a paused scheduler checks again, so the
+// refresh it held back follows shortly after the pause
+const PausedRetryInterval = time.Second
+
+// timerIDs numbers the schedulers and notifications, so their timers only
+// reach the one that started them
+var timerIDs atomic.Int64
+
+// SchedulerTickMsg is sent by the scheduler with the given id and sequence
+// number when it's time to refresh. The app sets Paused while a modal is open
+// or an input has focus
+type SchedulerTickMsg struct {
+	id     int64
+	seq    int
+	Paused bool
+}
+
+// SchedulerModel tells a page to refetch its data every interval. A zero
+// interval turns it off
+type SchedulerModel struct {
+	id       int64
+	seq      int
+	interval time.Duration
+}
+
+// NewSchedulerModel creates a scheduler that ticks every interval once Init
+// was run
+func NewSchedulerModel(interval time.Duration) SchedulerModel {
+	return SchedulerModel{id: timerIDs.Add(1), interval: interval}
+}
+
+// Interval returns the time between refreshes, 0 when turned off
+func (s SchedulerModel) Interval() time.Duration {
+	return s.interval
+}
+
+// SetInterval changes the time between refreshes and starts counting anew
+func (s *SchedulerModel) SetInterval(interval time.Duration) tea.Cmd {
+	s.seq++
+	s.interval = interval
+	return s.tick(interval)
+}
+
+func (s SchedulerModel) Init() tea.Cmd {
+	return s.tick(s.interval)
+}
+
+// Update reports whether msg says it's time to refresh. Paused ticks are
+// held back and checked again shortly
+func (s SchedulerModel) Update(msg tea.Msg) (SchedulerModel, bool, tea.Cmd) {
+	tick, ok := msg.(SchedulerTickMsg)
+	if !ok || tick.id != s.id || tick.seq != s.seq {
+		return s, false, nil
+	}
+	if tick.Paused {
+		return s, false, s.tick(PausedRetryInterval)
+	}
+	return s, true, s.tick(s.interval)
+}
+
+func (s SchedulerModel) tick(after time.Duration) tea.Cmd {
+	if s.interval <= 0 {
+		return nil
+	}
+	msg := SchedulerTickMsg{id: s.id, seq: s.seq}
+	return tea.Tick(after, func(time.Time) tea.Msg {
+		return msg
+	})
+}
@@ -0,0 +1,104 @@
+// app/components/select.go
+package components
+
+import (
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+// SelectModel lets the user pick one of a few options shown side by side.
+// It implements FocusableItem, so it can sit in a NavigationManager
+type SelectModel struct {
+	id       string
+	Options  []string
+	selected int
+	focused  bool
+}
+
+// NewSelectModel creates a select with the first option picked
+func NewSelectModel(options ...string) *SelectModel {
+	return &SelectModel{
+		id:      zone.NewPrefix(),
+		Options: options,
+	}
+}
+
+// Focus sets focus on the select
+func (s *SelectModel) Focus() {
+	s.focused = true
+}
+
+// Blur removes focus from the select
+func (s *SelectModel) Blur() {
+	s.focused = false
+}
+
+// IsFocused returns whether the select is focused
+func (s *SelectModel) IsFocused() bool {
+	return s.focused
+}
+
+// Value returns the picked option
+func (s *SelectModel) Value() string {
+	if len(s.Options) == 0 {
+		return ""
+	}
+	return s.Options[s.selected]
+}
+
+// SetValue picks option value. Unknown values pick the first option
+func (s *SelectModel) SetValue(value string) {
+	s.selected = max(slices.Index(s.Options, value), 0)
+}
+
+// Next picks the option after the current one, wrapping around
+func (s *SelectModel) Next() {
+	if len(s.Options) > 0 {
+		s.selected = (s.selected + 1) % len(s.Options)
+	}
+}
+
+// Previous picks the option before the current one, wrapping around
+func (s *SelectModel) Previous() {
+	if len(s.Options) > 0 {
+		s.selected = (s.selected - 1 + len(s.Options)) % len(s.Options)
+	}
+}
+
+// HandleMouse picks the clicked option. It reports whether an option was hit
+func (s *SelectModel) HandleMouse(msg tea.MouseMsg) bool {
+	for i := range s.Options {
+		if Clicked(s.optionID(i), msg) {
+			s.selected = i
+			return true
+		}
+	}
+	return false
+}
+
+// View renders all options with the picked one highlighted
+func (s *SelectModel) View() string {
+	views := make([]string, len(s.Options))
+	for i, option := range s.Options {
+		style := lipgloss.NewStyle().Padding(0, 1).Foreground(lipgloss.Color("#888888"))
+		if i == s.selected {
+			style = style.
+				Bold(true).
+				Foreground(lipgloss.Color("#FFFDF5")).
+				Background(lipgloss.Color("#2F4858"))
+			if s.focused {
+				style = style.Background(lipgloss.Color("#25A065"))
+			}
+		}
+		views[i] = zone.Mark(s.optionID(i), style.Render(option))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, views...)
+}
+
+func (s *SelectModel) optionID(index int) string {
+	return fmt.Sprintf("%soption-%d", s.id, index)
+}
@@ -0,0 +1,359 @@
+// app/components/split_pane.go
+package components
+
+import (
+	"bubbletea-app/app/config"
+	"bubbletea-app/app/global"
+	"bubbletea-app/app/layout"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+const (
+	defaultSplitRatio = 0.5
+	minSplitRatio     = 0.1
+	maxSplitRatio     = 0.9
+	splitRatioStep    = 0.05
+	// collapsedPaneSize is how much of a collapsed pane stays visible
+	collapsedPaneSize = 3
+)
+
+// SplitPaneModel hosts two models side by side (layout.Horizontal) or
+// stacked (layout.Vertical). Keys go to the focused pane, mouse events to
+// the pane under the pointer and everything else to both
+type SplitPaneModel struct {
+	id        string
+	name      string
+	direction layout.Direction
+	panes     [2]tea.Model
+	ratio     float64
+	focused   int
+	maximized int // index of the maximized pane, or -1
+	collapsed int // index of the collapsed pane, or -1
+	active    bool
+	editing   bool
+	keyMap    config.KeyMap
+	notice    NotificationModel
+	width     int
+	height    int
+}
+
+// NewSplitPane creates a split pane. name identifies it in the saved pane
+// config, so its ratio is restored in the next session
+func NewSplitPane(name string, direction layout.Direction, first, second tea.Model, keyMap config.KeyMap) SplitPaneModel {
+	ratio := defaultSplitRatio
+	if ratios, err := config.LoadPaneRatios(); err == nil {
+		if saved, ok := ratios[name]; ok {
+			ratio = clampRatio(saved)
+		}
+	}
+
+	m := SplitPaneModel{
+		id:        zone.NewPrefix(),
+		name:      name,
+		direction: direction,
+		panes:     [2]tea.Model{first, second},
+		ratio:     ratio,
+		notice:    NewNotificationModel(),
+		maximized: -1,
+		collapsed: -1,
+		keyMap:    keyMap,
+	}
+	// Only the focused pane keeps page focus
+	m.panes[1], _ = m.panes[1].Update(global.PageFocusChangedMsg(false))
+	return m
+}
+
+// PaneRatioSavedMsg reports the result of persisting the split ratio
+type PaneRatioSavedMsg struct {
+	Name string
+	Err  error
+}
+
+// Ratio returns the share of the space given to the first pane
+func (m SplitPaneModel) Ratio() float64 {
+	return m.ratio
+}
+
+// Focused returns the index of the pane that receives keys
+func (m SplitPaneModel) Focused() int {
+	return m.focused
+}
+
+// Searchable reports whether the focused pane can search, see
+// global.Searcher
+func (m SplitPaneModel) Searchable() bool {
+	searcher, ok := m.panes[m.focused].(global.Searcher)
+	return ok && searcher.Searchable()
+}
+
+// Pane returns the model shown in pane index
+func (m SplitPaneModel) Pane(index int) tea.Model {
+	return m.panes[index]
+}
+
+func (m SplitPaneModel) Init() tea.Cmd {
+	return tea.Batch(m.panes[0].Init(), m.panes[1].Init())
+}
+
+func (m SplitPaneModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m.resizePanes()
+
+	case global.InputFocusChangedMsg:
+		// Pane keys must not steal keys from an input being edited
+		m.editing = bool(msg)
+		return m, nil
+
+	case global.PageFocusChangedMsg:
+		m.active = bool(msg)
+		return m.updatePane(m.focused, msg)
+
+	case global.SearchMsg:
+		// Only the focused pane searches
+		return m.updatePane(m.focused, msg)
+
+	case PaneRatioSavedMsg:
+		if msg.Name != m.name || msg.Err == nil {
+			return m, nil
+		}
+		return m, m.notice.ShowError(fmt.Sprintf("Could not save the pane sizes: %v", msg.Err))
+
+	case NotificationExpiredMsg:
+		m.notice, _ = m.notice.Update(msg)
+
+	case tea.KeyMsg:
+		if !m.editing {
+			switch {
+			case key.Matches(msg, m.keyMap.PaneFocus):
+				return m.focus(1 - m.focused)
+			case key.Matches(msg, m.keyMap.PaneGrow):
+				return m.resize(splitRatioStep)
+			case key.Matches(msg, m.keyMap.PaneShrink):
+				return m.resize(-splitRatioStep)
+			case key.Matches(msg, m.keyMap.PaneMaximize):
+				return m.toggleMaximize()
+			case key.Matches(msg, m.keyMap.PaneCollapse):
+				return m.toggleCollapse()
+			}
+		}
+		return m.updatePane(m.focused, msg)
+
+	case tea.MouseMsg:
+		// Mouse events go to the pane under the pointer, and clicking into
+		// the other pane moves focus there first
+		for i := range m.panes {
+			if !m.visible(i) || !zone.Get(m.paneID(i)).InBounds(msg) {
+				continue
+			}
+
+			var focusCmd tea.Cmd
+			if i != m.focused && Clicked(m.paneID(i), msg) {
+				var model tea.Model
+				model, focusCmd = m.focus(i)
+				m = model.(SplitPaneModel)
+			}
+			model, cmd := m.updatePane(i, msg)
+			return model, tea.Batch(focusCmd, cmd)
+		}
+		return m, nil
+	}
+
+	// Everything else (data, timers) goes to both panes
+	var cmds [2]tea.Cmd
+	m.panes[0], cmds[0] = m.panes[0].Update(msg)
+	m.panes[1], cmds[1] = m.panes[1].Update(msg)
+	return m, tea.Batch(cmds[0], cmds[1])
+}
+
+func (m SplitPaneModel) View() string {
+	views := make(map[string]string, 2)
+	rects := m.layout().Layout(m.width, m.height)
+
+	for i := range m.panes {
+		r := rects[paneName(i)]
+		if r.Width <= 0 || r.Height <= 0 {
+			continue
+		}
+
+		borderColor := lipgloss.Color("#AAAAAA")
+		if i == m.focused && m.active {
+			borderColor = lipgloss.Color("#25A065")
+		}
+
+		content := ""
+		if i != m.collapsed {
+			content = layout.Fit(m.panes[i].View(), r.Width-2, r.Height-2)
+		}
+		views[paneName(i)] = zone.Mark(m.paneID(i), lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(borderColor).
+			Width(max(r.Width-2, 0)).
+			Height(max(r.Height-2, 0)).
+			Render(content))
+	}
+
+	view := m.layout().Render(m.width, m.height, views)
+	if !m.notice.Visible() {
+		return view
+	}
+	// The notice covers the bottom border for the short while it shows
+	lines := strings.Split(view, "\n")
+	notice := lipgloss.NewStyle().Padding(0, 1).MaxWidth(m.width).Render(m.notice.View())
+	lines[len(lines)-1] = lipgloss.PlaceHorizontal(m.width, lipgloss.Left, notice)
+	return strings.Join(lines, "\n")
+}
+
+// layout turns the ratio, collapse and maximize state into regions
+func (m SplitPaneModel) layout() layout.Flex {
+	direction := m.currentDirection()
+	total := m.height
+	if direction == layout.Horizontal {
+		total = m.width
+	}
+
+	first := int(math.Round(float64(total) * m.ratio))
+	switch {
+	case m.maximized == 0:
+		first = total
+	case m.maximized == 1:
+		first = 0
+	case m.collapsed == 0:
+		first = collapsedPaneSize
+	case m.collapsed == 1:
+		first = total - collapsedPaneSize
+	}
+
+	return layout.Flex{
+		Direction: direction,
+		Regions: []layout.Region{
+			layout.Fixed(paneName(0), first),
+			layout.Flexible(paneName(1), 1),
+		},
+	}
+}
+
+// currentDirection stacks side by side panes on compact terminals, where
+// each would be too narrow to be useful
+func (m SplitPaneModel) currentDirection() layout.Direction {
+	if m.direction == layout.Horizontal && layout.Compact(m.width) {
+		return layout.Vertical
+	}
+	return m.direction
+}
+
+// resizePanes tells each pane how much room it has inside its border
+func (m SplitPaneModel) resizePanes() (tea.Model, tea.Cmd) {
+	rects := m.layout().Layout(m.width, m.height)
+
+	var cmds [2]tea.Cmd
+	for i := range m.panes {
+		r := rects[paneName(i)]
+		m.panes[i], cmds[i] = m.panes[i].Update(tea.WindowSizeMsg{
+			Width:  max(r.Width-2, 0),
+			Height: max(r.Height-2, 0),
+		})
+	}
+	return m, tea.Batch(cmds[0], cmds[1])
+}
+
+// updatePane sends msg to a single pane
+func (m SplitPaneModel) updatePane(index int, msg tea.Msg) (tea.Model, tea.Cmd) {
+	return m.UpdatePane(index, msg)
+}
+
+// UpdatePane sends msg to pane index alone, for a page that shows the pane
+// on its own
+func (m SplitPaneModel) UpdatePane(index int, msg tea.Msg) (SplitPaneModel, tea.Cmd) {
+	var cmd tea.Cmd
+	m.panes[index], cmd = m.panes[index].Update(msg)
+	return m, cmd
+}
+
+// focus moves key focus to pane index, bringing it back if it was hidden
+func (m SplitPaneModel) focus(index int) (tea.Model, tea.Cmd) {
+	if index == m.focused {
+		return m, nil
+	}
+
+	var blurCmd, focusCmd tea.Cmd
+	m.panes[m.focused], blurCmd = m.panes[m.focused].Update(global.PageFocusChangedMsg(false))
+	m.focused = index
+	m.panes[m.focused], focusCmd = m.panes[m.focused].Update(global.PageFocusChangedMsg(m.active))
+
+	if !m.visible(index) || m.collapsed == index {
+		m.maximized = -1
+		m.collapsed = -1
+		model, sizeCmd := m.resizePanes()
+		return model, tea.Batch(blurCmd, focusCmd, sizeCmd)
+	}
+	return m, tea.Batch(blurCmd, focusCmd)
+}
+
+// resize moves the divider by delta and saves the new ratio
+func (m SplitPaneModel) resize(delta float64) (tea.Model, tea.Cmd) {
+	if m.maximized >= 0 || m.collapsed >= 0 {
+		return m, nil
+	}
+
+	m.ratio = clampRatio(m.ratio + delta)
+	model, cmd := m.resizePanes()
+	return model, tea.Batch(cmd, m.saveRatio())
+}
+
+func (m SplitPaneModel) toggleMaximize() (tea.Model, tea.Cmd) {
+	if m.maximized == m.focused {
+		m.maximized = -1
+	} else {
+		m.maximized = m.focused
+		m.collapsed = -1
+	}
+	return m.resizePanes()
+}
+
+// toggleCollapse shrinks the pane without focus to a thin strip
+func (m SplitPaneModel) toggleCollapse() (tea.Model, tea.Cmd) {
+	other := 1 - m.focused
+	if m.collapsed == other {
+		m.collapsed = -1
+	} else {
+		m.collapsed = other
+		m.maximized = -1
+	}
+	return m.resizePanes()
+}
+
+// saveRatio persists the ratio in the background
+func (m SplitPaneModel) saveRatio() tea.Cmd {
+	name, ratio := m.name, m.ratio
+	return func() tea.Msg {
+		return PaneRatioSavedMsg{Name: name, Err: config.SavePaneRatio(name, ratio)}
+	}
+}
+
+// visible reports whether pane index takes up any space
+func (m SplitPaneModel) visible(index int) bool {
+	return m.maximized < 0 || m.maximized == index
+}
+
+func (m SplitPaneModel) paneID(index int) string {
+	return fmt.Sprintf("%spane-%d", m.id, index)
+}
+
+func paneName(index int) string {
+	return fmt.Sprintf("pane-%d", index)
+}
+
+func clampRatio(ratio float64) float64 {
+	ratio = math.Round(ratio/splitRatioStep) * splitRatioStep
+	return math.Max(minSplitRatio, math.Min(maxSplitRatio, ratio))
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// FiltersFileName returns the full path to the saved search filters file
+func FiltersFileName() string {
+	return filepath.Join(GetConfigPath(), "filters.json")
+}
+
+// LoadSavedFilters loads the saved search queries, newest first
+func LoadSavedFilters() ([]string, error) {
+	var filters []string
+
+	data, err := os.ReadFile(FiltersFileName())
+	if os.IsNotExist(err) {
+		return filters, nil
+	}
+	if err != nil {
+		return filters, err
+	}
+
+	if err := json.Unmarshal(data, &filters); err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+// SaveFilters replaces the saved search queries
+func SaveFilters(filters []string) error {
+	if err := os.MkdirAll(GetConfigPath(), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(filters, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(FiltersFileName(), data, 0644)
+}
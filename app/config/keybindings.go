@@ -38,21 +38,47 @@ func GetConfigPath() string {
 
 // KeyMap defines keybindings for the application
 type KeyMap struct {
-	Up       key.Binding
-	Down     key.Binding
-	Left     key.Binding
-	Right    key.Binding
-	Help     key.Binding
-	Quit     key.Binding
-	Home     key.Binding
-	Settings key.Binding
-	About    key.Binding
-	Enter    key.Binding
-	Esc      key.Binding
-	Back     key.Binding
-	J        key.Binding
-	K        key.Binding
-	Ctrl     key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	Left      key.Binding
+	Right     key.Binding
+	Help      key.Binding
+	Quit      key.Binding
+	Home      key.Binding
+	Settings  key.Binding
+	About     key.Binding
+	Workspace key.Binding
+	Enter     key.Binding
+	Esc       key.Binding
+	Back      key.Binding
+	J         key.Binding
+	K         key.Binding
+	Ctrl      key.Binding
+	Tab       key.Binding
+	ShiftTab  key.Binding
+	Refresh   key.Binding
+	Add       key.Binding
+	Edit      key.Binding
+	Delete    key.Binding
+	Duplicate key.Binding
+	Undo      key.Binding
+	Redo      key.Binding
+	Search    key.Binding
+	SaveQuery key.Binding
+	Sort      key.Binding
+	Status    key.Binding
+	Select    key.Binding
+	Export    key.Binding
+	Import    key.Binding
+	Copy      key.Binding
+	Paste     key.Binding
+	Debug     key.Binding
+
+	PaneFocus    key.Binding
+	PaneGrow     key.Binding
+	PaneShrink   key.Binding
+	PaneMaximize key.Binding
+	PaneCollapse key.Binding
 }
 
 // DefaultKeyMap returns the default keybindings
@@ -94,6 +120,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("3"),
 			key.WithHelp("3", "about page"),
 		),
+		Workspace: key.NewBinding(
+			key.WithKeys("4"),
+			key.WithHelp("4", "workspace page"),
+		),
 		Enter: key.NewBinding(
 			key.WithKeys("enter"),
 			key.WithHelp("enter", "confirm"),
@@ -118,6 +148,102 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("ctrl"),
 			key.WithHelp("ctrl", ""),
 		),
+		Tab: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next field"),
+		),
+		ShiftTab: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "previous field"),
+		),
+		Refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh data"),
+		),
+		Add: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "add item"),
+		),
+		Edit: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit item"),
+		),
+		Delete: key.NewBinding(
+			key.WithKeys("d", "delete"),
+			key.WithHelp("d", "delete item"),
+		),
+		Duplicate: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "duplicate item"),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "undo"),
+		),
+		Redo: key.NewBinding(
+			key.WithKeys("U", "ctrl+r"),
+			key.WithHelp("U", "redo"),
+		),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search"),
+		),
+		SaveQuery: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("ctrl+s", "save/forget filter"),
+		),
+		Sort: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "change sort order"),
+		),
+		Status: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "change status"),
+		),
+		Select: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "select item"),
+		),
+		Export: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "export items"),
+		),
+		Import: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "import items"),
+		),
+		Copy: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "copy item or field"),
+		),
+		Paste: key.NewBinding(
+			key.WithKeys("ctrl+v"),
+			key.WithHelp("ctrl+v", "paste into input"),
+		),
+		Debug: key.NewBinding(
+			key.WithKeys("ctrl+t"),
+			key.WithHelp("ctrl+t", "debug overlay"),
+		),
+		PaneFocus: key.NewBinding(
+			key.WithKeys("ctrl+w"),
+			key.WithHelp("ctrl+w", "switch pane"),
+		),
+		PaneGrow: key.NewBinding(
+			key.WithKeys("ctrl+right", "ctrl+down", "+"),
+			key.WithHelp("+", "grow first pane"),
+		),
+		PaneShrink: key.NewBinding(
+			key.WithKeys("ctrl+left", "ctrl+up", "-"),
+			key.WithHelp("-", "shrink first pane"),
+		),
+		PaneMaximize: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("ctrl+o", "maximize pane"),
+		),
+		PaneCollapse: key.NewBinding(
+			key.WithKeys("ctrl+x"),
+			key.WithHelp("ctrl+x", "collapse other pane"),
+		),
 	}
 }
 
@@ -1,5 +1,12 @@
 package config
 
+import (
+	"sort"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
 // FocusableItem defines an interface for items that can receive focus
 type FocusableItem interface {
 	Focus()
@@ -7,10 +14,41 @@ type FocusableItem interface {
 	IsFocused() bool
 }
 
-// NavigationManager handles focus navigation between items
+// DisableableItem is implemented by focusable items that can be switched off.
+// Disabled items are skipped by every kind of navigation
+type DisableableItem interface {
+	IsDisabled() bool
+}
+
+// Direction is used for spatial (grid) navigation
+type Direction int
+
+const (
+	DirUp Direction = iota
+	DirDown
+	DirLeft
+	DirRight
+)
+
+// GridPos is the row and column an item occupies in its group
+type GridPos struct {
+	Row int
+	Col int
+}
+
+// NavigationManager handles focus navigation between items. It implements
+// FocusableItem itself, so a manager can be added to another manager as a
+// nested focus group
 type NavigationManager struct {
 	Items        []FocusableItem
 	CurrentFocus int
+	// Wrap lets focus wrap around at the edges of the top level group
+	Wrap bool
+
+	positions  []GridPos
+	tabIndexes []int
+	lastFocus  int
+	parent     *NavigationManager
 }
 
 // NewNavigationManager creates a new navigation manager
@@ -18,46 +56,381 @@ func NewNavigationManager() *NavigationManager {
 	return &NavigationManager{
 		Items:        make([]FocusableItem, 0),
 		CurrentFocus: -1,
+		Wrap:         true,
+		lastFocus:    -1,
 	}
 }
 
-// AddItem adds a focusable item to the manager
+// AddItem adds a focusable item to the manager on a new row
 func (nm *NavigationManager) AddItem(item FocusableItem) {
+	row := 0
+	for _, pos := range nm.positions {
+		if pos.Row >= row {
+			row = pos.Row + 1
+		}
+	}
+	nm.AddItemAt(item, row, 0)
+}
+
+// AddItemAt adds a focusable item at the given grid position
+func (nm *NavigationManager) AddItemAt(item FocusableItem, row, col int) {
+	if group, ok := item.(*NavigationManager); ok {
+		group.parent = nm
+	}
 	nm.Items = append(nm.Items, item)
+	nm.positions = append(nm.positions, GridPos{Row: row, Col: col})
+	nm.tabIndexes = append(nm.tabIndexes, 0)
 }
 
-// Next focuses the next item in the list
-func (nm *NavigationManager) Next() {
-	if len(nm.Items) == 0 {
-		return
+// SetTabIndex changes where item sits in the tab order. Items with equal
+// indexes keep the order they were added in
+func (nm *NavigationManager) SetTabIndex(item FocusableItem, index int) {
+	if i := nm.indexOf(item); i >= 0 {
+		nm.tabIndexes[i] = index
 	}
+}
 
-	// Blur current item
-	if nm.CurrentFocus >= 0 && nm.CurrentFocus < len(nm.Items) {
-		nm.Items[nm.CurrentFocus].Blur()
+// Current returns the focused item of this group, or nil
+func (nm *NavigationManager) Current() FocusableItem {
+	if nm.CurrentFocus < 0 || nm.CurrentFocus >= len(nm.Items) {
+		return nil
 	}
+	return nm.Items[nm.CurrentFocus]
+}
 
-	// Move to next item
-	nm.CurrentFocus = (nm.CurrentFocus + 1) % len(nm.Items)
-	nm.Items[nm.CurrentFocus].Focus()
+// Leaf returns the focused item, descending into nested groups
+func (nm *NavigationManager) Leaf() FocusableItem {
+	current := nm.Current()
+	if group, ok := current.(*NavigationManager); ok {
+		return group.Leaf()
+	}
+	return current
 }
 
-// Previous focuses the previous item in the list
-func (nm *NavigationManager) Previous() {
-	if len(nm.Items) == 0 {
+// FocusItem moves focus to item, which may live in a nested group
+func (nm *NavigationManager) FocusItem(item FocusableItem) bool {
+	for i, candidate := range nm.Items {
+		if candidate == item {
+			if !isEnabled(candidate) {
+				return false
+			}
+			nm.focusIndex(i, enterRestore)
+			return true
+		}
+		if group, ok := candidate.(*NavigationManager); ok && group.contains(item) {
+			if nm.CurrentFocus != i {
+				nm.blurCurrent()
+			}
+			nm.CurrentFocus = i
+			nm.lastFocus = i
+			return group.FocusItem(item)
+		}
+	}
+	return false
+}
+
+// Next focuses the next item in the tab order
+func (nm *NavigationManager) Next() bool {
+	return nm.tab(1)
+}
+
+// Previous focuses the previous item in the tab order
+func (nm *NavigationManager) Previous() bool {
+	return nm.tab(-1)
+}
+
+// Move focuses the nearest item in the given direction. Nested groups get the
+// first chance to handle the move; when they have nothing left in that
+// direction, focus leaves the group
+func (nm *NavigationManager) Move(dir Direction) bool {
+	if nm.CurrentFocus < 0 {
+		return nm.enter(enterFirst)
+	}
+
+	if group, ok := nm.Current().(*NavigationManager); ok && group.Move(dir) {
+		return true
+	}
+
+	if target := nm.nearest(dir); target >= 0 {
+		nm.focusIndex(target, enterRestore)
+		return true
+	}
+
+	if nm.parent == nil && nm.Wrap {
+		if target := nm.wrapTarget(dir); target >= 0 && target != nm.CurrentFocus {
+			nm.focusIndex(target, enterRestore)
+			return true
+		}
+	}
+	return false
+}
+
+// HandleKey moves focus for arrow and tab keys. It reports whether msg was a
+// navigation key, even if focus could not move any further
+func (nm *NavigationManager) HandleKey(msg tea.KeyMsg, keyMap KeyMap) bool {
+	switch {
+	case key.Matches(msg, keyMap.Tab):
+		nm.Next()
+	case key.Matches(msg, keyMap.ShiftTab):
+		nm.Previous()
+	case key.Matches(msg, keyMap.Up):
+		nm.Move(DirUp)
+	case key.Matches(msg, keyMap.Down):
+		nm.Move(DirDown)
+	case key.Matches(msg, keyMap.Left):
+		nm.Move(DirLeft)
+	case key.Matches(msg, keyMap.Right):
+		nm.Move(DirRight)
+	default:
+		return false
+	}
+	return true
+}
+
+// Focus gives focus back to the item that had it last, or the first item
+func (nm *NavigationManager) Focus() {
+	nm.enter(enterRestore)
+}
+
+// Blur removes focus from the group and remembers which item had it
+func (nm *NavigationManager) Blur() {
+	if nm.CurrentFocus >= 0 {
+		nm.blurCurrent()
+		nm.lastFocus = nm.CurrentFocus
+		nm.CurrentFocus = -1
+	}
+}
+
+// IsFocused returns whether any item in the group is focused
+func (nm *NavigationManager) IsFocused() bool {
+	return nm.CurrentFocus >= 0
+}
+
+// IsDisabled returns whether the group has no enabled items to focus
+func (nm *NavigationManager) IsDisabled() bool {
+	for _, item := range nm.Items {
+		if isEnabled(item) {
+			return false
+		}
+	}
+	return true
+}
+
+type enterMode int
+
+const (
+	enterFirst enterMode = iota
+	enterLast
+	enterRestore
+)
+
+// enter focuses an item of a group that did not have focus
+func (nm *NavigationManager) enter(mode enterMode) bool {
+	switch mode {
+	case enterFirst:
+		return nm.tab(1)
+	case enterLast:
+		return nm.tab(-1)
+	}
+
+	if nm.lastFocus >= 0 && nm.lastFocus < len(nm.Items) && isEnabled(nm.Items[nm.lastFocus]) {
+		nm.focusIndex(nm.lastFocus, enterRestore)
+		return true
+	}
+	return nm.tab(1)
+}
+
+// tab walks the tab order by delta, descending into nested groups first
+func (nm *NavigationManager) tab(delta int) bool {
+	order := nm.tabOrder()
+	n := len(order)
+	if n == 0 {
+		return false
+	}
+
+	pos := -1
+	if delta < 0 {
+		pos = n
+	}
+	for i, idx := range order {
+		if idx == nm.CurrentFocus {
+			pos = i
+			if group, ok := nm.Items[idx].(*NavigationManager); ok && group.tab(delta) {
+				return true
+			}
+			break
+		}
+	}
+
+	mode := enterFirst
+	if delta < 0 {
+		mode = enterLast
+	}
+	for step := 1; step <= n; step++ {
+		p := pos + delta*step
+		if p < 0 || p >= n {
+			// Nested groups hand focus back to their parent at the edges
+			if nm.parent != nil || !nm.Wrap {
+				return false
+			}
+			p = (p%n + n) % n
+		}
+		if idx := order[p]; isEnabled(nm.Items[idx]) {
+			nm.focusIndex(idx, mode)
+			return true
+		}
+	}
+	return false
+}
+
+// tabOrder returns item indexes sorted by tab index, then insertion order
+func (nm *NavigationManager) tabOrder() []int {
+	order := make([]int, len(nm.Items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return nm.tabIndexes[order[a]] < nm.tabIndexes[order[b]]
+	})
+	return order
+}
+
+// nearest finds the closest enabled item in direction dir. Vertical moves may
+// change column, horizontal moves stay on the current row
+func (nm *NavigationManager) nearest(dir Direction) int {
+	from := nm.positions[nm.CurrentFocus]
+	best, bestMain, bestCross := -1, 0, 0
+
+	for i, item := range nm.Items {
+		if i == nm.CurrentFocus || !isEnabled(item) {
+			continue
+		}
+		to := nm.positions[i]
+
+		var mainDist, crossDist int
+		switch dir {
+		case DirUp:
+			mainDist, crossDist = from.Row-to.Row, abs(from.Col-to.Col)
+		case DirDown:
+			mainDist, crossDist = to.Row-from.Row, abs(from.Col-to.Col)
+		case DirLeft:
+			if to.Row != from.Row {
+				continue
+			}
+			mainDist = from.Col - to.Col
+		case DirRight:
+			if to.Row != from.Row {
+				continue
+			}
+			mainDist = to.Col - from.Col
+		}
+		if mainDist <= 0 {
+			continue
+		}
+
+		if best < 0 || mainDist < bestMain || (mainDist == bestMain && crossDist < bestCross) {
+			best, bestMain, bestCross = i, mainDist, crossDist
+		}
+	}
+	return best
+}
+
+// wrapTarget finds the item on the opposite edge of the grid from dir
+func (nm *NavigationManager) wrapTarget(dir Direction) int {
+	from := nm.positions[nm.CurrentFocus]
+	best := -1
+
+	for i, item := range nm.Items {
+		if !isEnabled(item) {
+			continue
+		}
+		to := nm.positions[i]
+		if (dir == DirLeft || dir == DirRight) && to.Row != from.Row {
+			continue
+		}
+		if best < 0 {
+			best = i
+			continue
+		}
+
+		current := nm.positions[best]
+		switch dir {
+		case DirDown:
+			if to.Row < current.Row || (to.Row == current.Row && abs(to.Col-from.Col) < abs(current.Col-from.Col)) {
+				best = i
+			}
+		case DirUp:
+			if to.Row > current.Row || (to.Row == current.Row && abs(to.Col-from.Col) < abs(current.Col-from.Col)) {
+				best = i
+			}
+		case DirRight:
+			if to.Col < current.Col {
+				best = i
+			}
+		case DirLeft:
+			if to.Col > current.Col {
+				best = i
+			}
+		}
+	}
+	return best
+}
+
+// focusIndex moves focus to the item at index, entering groups with mode
+func (nm *NavigationManager) focusIndex(index int, mode enterMode) {
+	if index != nm.CurrentFocus {
+		nm.blurCurrent()
+	}
+	nm.CurrentFocus = index
+	nm.lastFocus = index
+
+	if group, ok := nm.Items[index].(*NavigationManager); ok {
+		group.enter(mode)
 		return
 	}
+	nm.Items[index].Focus()
+}
+
+// blurCurrent blurs the focused item, if any
+func (nm *NavigationManager) blurCurrent() {
+	if current := nm.Current(); current != nil {
+		current.Blur()
+	}
+}
 
-	// Blur current item
-	if nm.CurrentFocus >= 0 && nm.CurrentFocus < len(nm.Items) {
-		nm.Items[nm.CurrentFocus].Blur()
+// indexOf returns the index of item in this group, or -1
+func (nm *NavigationManager) indexOf(item FocusableItem) int {
+	for i, candidate := range nm.Items {
+		if candidate == item {
+			return i
+		}
 	}
+	return -1
+}
+
+// contains reports whether item is in this group or one of its subgroups
+func (nm *NavigationManager) contains(item FocusableItem) bool {
+	for _, candidate := range nm.Items {
+		if candidate == item {
+			return true
+		}
+		if group, ok := candidate.(*NavigationManager); ok && group.contains(item) {
+			return true
+		}
+	}
+	return false
+}
+
+func isEnabled(item FocusableItem) bool {
+	if d, ok := item.(DisableableItem); ok {
+		return !d.IsDisabled()
+	}
+	return true
+}
 
-	// Move to previous item
-	if nm.CurrentFocus <= 0 {
-		nm.CurrentFocus = len(nm.Items) - 1
-	} else {
-		nm.CurrentFocus--
+func abs(v int) int {
+	if v < 0 {
+		return -v
 	}
-	nm.Items[nm.CurrentFocus].Focus()
+	return v
 }
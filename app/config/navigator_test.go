@@ -0,0 +1,166 @@
+// app/config/navigator_test.go
+package config
+
+import "testing"
+
+// testItem is a focusable item that tracks its focus
+type testItem struct {
+	name     string
+	focused  bool
+	disabled bool
+}
+
+func (i *testItem) Focus()           { i.focused = true }
+func (i *testItem) Blur()            { i.focused = false }
+func (i *testItem) IsFocused() bool  { return i.focused }
+func (i *testItem) IsDisabled() bool { return i.disabled }
+
+// navSteps are the moves a navigation test makes, by name
+var navSteps = map[string]func(nav *NavigationManager){
+	"up":        func(nav *NavigationManager) { nav.Move(DirUp) },
+	"down":      func(nav *NavigationManager) { nav.Move(DirDown) },
+	"left":      func(nav *NavigationManager) { nav.Move(DirLeft) },
+	"right":     func(nav *NavigationManager) { nav.Move(DirRight) },
+	"tab":       func(nav *NavigationManager) { nav.Next() },
+	"shift+tab": func(nav *NavigationManager) { nav.Previous() },
+}
+
+// runNav focuses the item named from, makes the moves in steps and checks
+// focus ends up on the item named want, and only there
+func runNav(t *testing.T, nav *NavigationManager, items map[string]*testItem, from string, steps []string, want string) {
+	t.Helper()
+	if !nav.FocusItem(items[from]) {
+		t.Fatalf("can't focus %s", from)
+	}
+	for _, step := range steps {
+		navSteps[step](nav)
+	}
+	leaf, _ := nav.Leaf().(*testItem)
+	if leaf == nil || leaf.name != want {
+		t.Fatalf("focus is on %v, want %s", nav.Leaf(), want)
+	}
+	for name, item := range items {
+		if item.IsFocused() != (name == want) {
+			t.Errorf("%s has focus %t", name, item.IsFocused())
+		}
+	}
+}
+
+func TestGridNavigation(t *testing.T) {
+	// a b c
+	// d e f   e is disabled
+	// g
+	newGrid := func() (*NavigationManager, map[string]*testItem) {
+		nav := NewNavigationManager()
+		items := map[string]*testItem{}
+		for i, name := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+			items[name] = &testItem{name: name, disabled: name == "e"}
+			nav.AddItemAt(items[name], i/3, i%3)
+		}
+		return nav, items
+	}
+	tests := []struct {
+		name  string
+		from  string
+		steps []string
+		want  string
+	}{
+		{"right", "a", []string{"right"}, "b"},
+		{"left", "c", []string{"left"}, "b"},
+		{"down", "c", []string{"down"}, "f"},
+		{"up", "f", []string{"up"}, "c"},
+		{"down to a shorter row", "f", []string{"down"}, "g"},
+		{"up from a shorter row", "g", []string{"up"}, "d"},
+		{"right skips disabled", "d", []string{"right"}, "f"},
+		{"left skips disabled", "f", []string{"left"}, "d"},
+		{"down skips disabled", "b", []string{"down"}, "d"},
+		{"up wraps to the bottom", "a", []string{"up"}, "g"},
+		{"down wraps to the top", "g", []string{"down"}, "a"},
+		{"right wraps in the row", "c", []string{"right"}, "a"},
+		{"left wraps in the row", "d", []string{"left"}, "f"},
+		{"tab skips disabled", "d", []string{"tab"}, "f"},
+		{"tab wraps", "g", []string{"tab"}, "a"},
+		{"shift+tab wraps", "a", []string{"shift+tab"}, "g"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nav, items := newGrid()
+			runNav(t, nav, items, tt.from, tt.steps, tt.want)
+		})
+	}
+}
+
+func TestGroupNavigation(t *testing.T) {
+	// a, then a row with a group of x, y and z side by side, then b. z is
+	// disabled
+	newGroups := func() (*NavigationManager, map[string]*testItem) {
+		items := map[string]*testItem{}
+		for _, name := range []string{"a", "x", "y", "z", "b"} {
+			items[name] = &testItem{name: name, disabled: name == "z"}
+		}
+		group := NewNavigationManager()
+		group.AddItemAt(items["x"], 0, 0)
+		group.AddItemAt(items["y"], 0, 1)
+		group.AddItemAt(items["z"], 0, 2)
+		nav := NewNavigationManager()
+		nav.AddItem(items["a"])
+		nav.AddItem(group)
+		nav.AddItem(items["b"])
+		return nav, items
+	}
+	tests := []struct {
+		name  string
+		from  string
+		steps []string
+		want  string
+	}{
+		{"tab enters at the first", "a", []string{"tab"}, "x"},
+		{"tab goes through the group", "a", []string{"tab", "tab"}, "y"},
+		{"tab leaves past disabled", "y", []string{"tab"}, "b"},
+		{"tab wraps around the top", "b", []string{"tab", "tab"}, "x"},
+		{"shift+tab enters at the last", "b", []string{"shift+tab"}, "y"},
+		{"shift+tab leaves the group", "x", []string{"shift+tab"}, "a"},
+		{"shift+tab wraps around the top", "a", []string{"shift+tab"}, "b"},
+		{"right stays in the group", "x", []string{"right", "right"}, "y"},
+		{"down leaves the group", "y", []string{"down"}, "b"},
+		{"up comes back where it left", "y", []string{"down", "up"}, "y"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nav, items := newGroups()
+			runNav(t, nav, items, tt.from, tt.steps, tt.want)
+		})
+	}
+}
+
+func TestFocusItemInGroupIsRestored(t *testing.T) {
+	first, second := &testItem{name: "first"}, &testItem{name: "second"}
+	other := &testItem{name: "other"}
+
+	group := NewNavigationManager()
+	group.AddItem(first)
+	group.AddItem(second)
+	nav := NewNavigationManager()
+	nav.AddItem(other)
+	nav.AddItem(group)
+
+	nav.Focus()
+	if !other.IsFocused() {
+		t.Fatal("the first item didn't get focus")
+	}
+	if !nav.FocusItem(second) || !second.IsFocused() || other.IsFocused() {
+		t.Fatal("FocusItem didn't move focus into the group")
+	}
+
+	// Focusing the page again, or leaving and coming back, lands on the
+	// item FocusItem picked
+	for _, step := range []string{"focus", "blur and focus"} {
+		if step == "blur and focus" {
+			nav.Blur()
+		}
+		nav.Focus()
+		if !second.IsFocused() || first.IsFocused() || other.IsFocused() {
+			t.Errorf("after %s the focus is on %v, not the second item", step, nav.Leaf())
+		}
+	}
+}
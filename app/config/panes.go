@@ -0,0 +1,49 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// PanesFileName returns the full path to the split pane config file
+func PanesFileName() string {
+	return filepath.Join(GetConfigPath(), "panes.json")
+}
+
+// LoadPaneRatios loads the saved split ratios, keyed by split pane name
+func LoadPaneRatios() (map[string]float64, error) {
+	ratios := make(map[string]float64)
+
+	data, err := os.ReadFile(PanesFileName())
+	if os.IsNotExist(err) {
+		return ratios, nil
+	}
+	if err != nil {
+		return ratios, err
+	}
+
+	if err := json.Unmarshal(data, &ratios); err != nil {
+		return ratios, err
+	}
+	return ratios, nil
+}
+
+// SavePaneRatio stores the split ratio of one split pane, keeping the others
+func SavePaneRatio(name string, ratio float64) error {
+	ratios, err := LoadPaneRatios()
+	if err != nil {
+		// A broken file is replaced rather than blocking every later save
+		ratios = make(map[string]float64)
+	}
+	ratios[name] = ratio
+
+	if err := os.MkdirAll(GetConfigPath(), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(ratios, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(PanesFileName(), data, 0644)
+}
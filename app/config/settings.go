@@ -0,0 +1,126 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Data backends that can be picked on the Settings page
+const (
+	BackendREST   = "rest"
+	BackendFile   = "file"
+	BackendSQLite = "sqlite"
+	BackendMock   = "mock"
+)
+
+// Backends lists the data backends in the order the Settings page shows them
+var Backends = []string{BackendREST, BackendFile, BackendSQLite, BackendMock}
+
+// Settings holds the connection details edited on the Settings page
+type Settings struct {
+	Backend  string `json:"backend,omitempty"`
+	Host     string `json:"host"`
+	Port     string `json:"port"`
+	APIKey   string `json:"api_key"`
+	DataPath string `json:"data_path,omitempty"`
+	// RefreshInterval is how many seconds pass between background
+	// refreshes of Home, 0 turns them off
+	RefreshInterval int `json:"refresh_interval,omitempty"`
+}
+
+// SettingsFileName returns the full path to the settings file
+func SettingsFileName() string {
+	return filepath.Join(GetConfigPath(), "settings.json")
+}
+
+// DataBackend returns the selected backend, REST unless something else was
+// picked
+func (s Settings) DataBackend() string {
+	if s.Backend == "" {
+		return BackendREST
+	}
+	return s.Backend
+}
+
+// DataFile returns the file used by the file and SQLite backends. Without a
+// path set it lives next to the other config files
+func (s Settings) DataFile() string {
+	if s.DataPath != "" {
+		return s.DataPath
+	}
+	if s.DataBackend() == BackendSQLite {
+		return filepath.Join(GetConfigPath(), "items.db")
+	}
+	return filepath.Join(GetConfigPath(), "items.json")
+}
+
+// RefreshEvery returns the time between background refreshes, 0 when they
+// are turned off
+func (s Settings) RefreshEvery() time.Duration {
+	return time.Duration(max(s.RefreshInterval, 0)) * time.Second
+}
+
+// SameBackend reports whether s and other reach the same backend the same
+// way, so a connection opened for one serves the other
+func (s Settings) SameBackend(other Settings) bool {
+	s.RefreshInterval = 0
+	other.RefreshInterval = 0
+	return s == other
+}
+
+// Validate checks that the settings can be used to reach a server
+func (s Settings) Validate() error {
+	if !slices.Contains(Backends, s.DataBackend()) {
+		return fmt.Errorf("unknown backend %q: must be one of %s", s.Backend, strings.Join(Backends, ", "))
+	}
+	if s.RefreshInterval < 0 {
+		return fmt.Errorf("invalid refresh interval %d: can't be negative", s.RefreshInterval)
+	}
+	if s.Port == "" {
+		return nil
+	}
+	port, err := strconv.Atoi(s.Port)
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("invalid port %q: must be a number between 1 and 65535", s.Port)
+	}
+	return nil
+}
+
+// LoadSettings loads the saved settings, or empty settings if there are none
+func LoadSettings() (Settings, error) {
+	var s Settings
+
+	data, err := os.ReadFile(SettingsFileName())
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+
+	err = json.Unmarshal(data, &s)
+	return s, err
+}
+
+// SaveSettings writes the settings to the settings file
+func SaveSettings(s Settings) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(GetConfigPath(), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	// The file holds the API key, so keep it private
+	return os.WriteFile(SettingsFileName(), data, 0600)
+}
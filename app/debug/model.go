@@ -0,0 +1,197 @@
+// app/debug/model.go
+package debug
+
+import (
+	"bubbletea-app/app/config"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// jump is how many records up and down step over
+const jump = 10
+
+var (
+	overlayTitleStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFFFFF")).
+				Background(lipgloss.Color("#874BFD")).
+				Bold(true).
+				Padding(0, 1)
+	overlayTextStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	overlayInputStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#25A065")).Bold(true)
+	overlayRuleStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#874BFD"))
+)
+
+// Model wraps the app and records every message that reaches it. The Debug
+// key opens an overlay over the app that steps through the records and
+// shows the app as it was after each one. Keys go to the overlay while it's
+// open, everything else still goes to the app and gets recorded
+type Model struct {
+	app       Snapshotter
+	recording Recording
+	keyMap    config.KeyMap
+	// path is where the recording is exported to, a new file in the config
+	// dir if empty
+	path   string
+	open   bool
+	index  int
+	notice string
+	width  int
+	height int
+}
+
+// New wraps app in a recorder that exports to path
+func New(app Snapshotter, keyMap config.KeyMap, path string) Model {
+	return Model{
+		app:       app,
+		recording: NewRecording(DefaultLimit),
+		keyMap:    keyMap,
+		path:      path,
+	}
+}
+
+// App returns the app the model wraps
+func (m Model) App() Snapshotter {
+	return m.app
+}
+
+// Recording returns what was recorded so far
+func (m Model) Recording() Recording {
+	return m.recording
+}
+
+// Page returns the page the app shows, if it has pages
+func (m Model) Page() string {
+	if paged, ok := m.app.(interface{ Page() string }); ok {
+		return paged.Page()
+	}
+	return ""
+}
+
+// Snapshot returns the snapshot of the app
+func (m Model) Snapshot() Snapshot {
+	return m.app.Snapshot()
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.app.Init()
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		if key.Matches(msg, m.keyMap.Debug) {
+			m.open = !m.open
+			m.index = len(m.recording.Records) - 1
+			m.notice = ""
+			return m, nil
+		}
+		if m.open {
+			return m.handleKey(msg), nil
+		}
+	}
+	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		// Clicks would change the app behind the overlay
+		if m.open {
+			return m, nil
+		}
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	}
+
+	model, cmd := m.app.Update(msg)
+	m.app = model.(Snapshotter)
+	// The overlay stays on the record it shows when an old one is dropped
+	if dropped := m.recording.Add(msg, m.app.Snapshot()); dropped >= 0 && dropped < m.index {
+		m.index--
+	}
+	return m, cmd
+}
+
+// handleKey steps through the records, exports them or closes the overlay
+func (m Model) handleKey(msg tea.KeyMsg) Model {
+	last := len(m.recording.Records) - 1
+	switch {
+	case key.Matches(msg, m.keyMap.Left):
+		m.index = max(m.index-1, 0)
+	case key.Matches(msg, m.keyMap.Right):
+		m.index = min(m.index+1, last)
+	case key.Matches(msg, m.keyMap.Up):
+		m.index = max(m.index-jump, 0)
+	case key.Matches(msg, m.keyMap.Down):
+		m.index = min(m.index+jump, last)
+	case key.Matches(msg, m.keyMap.Export):
+		path := m.path
+		if path == "" {
+			path = RecordingPath()
+		}
+		if err := m.recording.Save(path); err != nil {
+			m.notice = "Export failed: " + err.Error()
+		} else {
+			m.notice = fmt.Sprintf("Exported %d records to %s", len(m.recording.Records), path)
+		}
+	case key.Matches(msg, m.keyMap.Esc), key.Matches(msg, m.keyMap.Back):
+		m.open = false
+	}
+	return m
+}
+
+func (m Model) View() string {
+	if !m.open {
+		return m.app.View()
+	}
+	if len(m.recording.Records) == 0 {
+		return overlayTitleStyle.Render("DEBUG") + " nothing recorded yet"
+	}
+
+	records := m.recording.Records
+	record := records[m.index]
+	since := record.Time.Sub(records[0].Time)
+	title := overlayTitleStyle.Render("DEBUG") + " " + overlayTextStyle.Render(fmt.Sprintf(
+		"%d/%d • %s • +%.3fs", m.index+1, len(records), record.Time.Format("15:04:05.000"), since.Seconds(),
+	))
+
+	msgType := record.Type
+	if record.Input != nil {
+		msgType = overlayInputStyle.Render(msgType)
+	}
+	msgLine := msgType + " " + record.Msg
+
+	snapshot := record.Snapshot
+	state := overlayTextStyle.Render(fmt.Sprintf("page %s • input focus %s • modal %s • help %s",
+		snapshot.Page, yesNo(snapshot.InputInFocus), yesNo(snapshot.ModalOpen), yesNo(snapshot.HelpOpen),
+	))
+
+	hint := m.notice
+	if hint == "" {
+		hint = fmt.Sprintf("%s/%s step • %s/%s %d steps • %s export • %s close",
+			m.keyMap.Left.Help().Key, m.keyMap.Right.Help().Key,
+			m.keyMap.Up.Help().Key, m.keyMap.Down.Help().Key, jump,
+			m.keyMap.Export.Help().Key, m.keyMap.Debug.Help().Key,
+		)
+	}
+	header := []string{title, msgLine, state, overlayTextStyle.Render(hint), overlayRuleStyle.Render(strings.Repeat("─", m.width))}
+	for i, line := range header {
+		header[i] = ansi.Truncate(line, m.width, "…")
+	}
+
+	// The app as it was gets the rest of the screen
+	screen := snapshot.View
+	if screen == "" {
+		screen = overlayTextStyle.Render(fmt.Sprintf("Only the last %d records keep the screen", m.recording.Limit))
+	}
+	view := strings.Split(screen, "\n")
+	view = view[:min(len(view), max(m.height-len(header), 0))]
+	return strings.Join(append(header, view...), "\n")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
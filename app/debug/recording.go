@@ -0,0 +1,178 @@
+// app/debug/recording.go
+
+// Package debug records the messages that reach the app, to go back and
+// forth through them in an overlay and to replay them in tests
+package debug
+
+import (
+	"bubbletea-app/app/config"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultLimit is how many of the latest records keep their rendered
+// screen, which takes the most room. Older records are trimmed, see
+// Recording
+const DefaultLimit = 1000
+
+// maxSummary is how long message summaries get
+const maxSummary = 200
+
+// Snapshot is what the app looked like after a message
+type Snapshot struct {
+	Page         string `json:"page"`
+	InputInFocus bool   `json:"input_in_focus"`
+	ModalOpen    bool   `json:"modal_open"`
+	HelpOpen     bool   `json:"help_open"`
+	View         string `json:"view"`
+}
+
+// Snapshotter is a model that can sum itself up for a recording
+type Snapshotter interface {
+	tea.Model
+	Snapshot() Snapshot
+}
+
+// Input is a message from the terminal, kept so that it can be replayed.
+// Everything else follows from the inputs
+type Input struct {
+	Key   *tea.Key           `json:"key,omitempty"`
+	Size  *tea.WindowSizeMsg `json:"size,omitempty"`
+	Mouse *tea.MouseEvent    `json:"mouse,omitempty"`
+}
+
+// inputOf returns msg as an Input, if it is one
+func inputOf(msg tea.Msg) *Input {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		key := tea.Key(msg)
+		return &Input{Key: &key}
+	case tea.WindowSizeMsg:
+		return &Input{Size: &msg}
+	case tea.MouseMsg:
+		mouse := tea.MouseEvent(msg)
+		return &Input{Mouse: &mouse}
+	}
+	return nil
+}
+
+// Msg returns the message the input was recorded from
+func (i Input) Msg() tea.Msg {
+	switch {
+	case i.Key != nil:
+		return tea.KeyMsg(*i.Key)
+	case i.Size != nil:
+		return *i.Size
+	case i.Mouse != nil:
+		return tea.MouseMsg(*i.Mouse)
+	}
+	return nil
+}
+
+// Record is a message that reached the app and what the app looked like
+// after it
+type Record struct {
+	Time time.Time `json:"time"`
+	// Type is the Go type of the message, like "tea.KeyMsg"
+	Type string `json:"type"`
+	// Msg sums up the message for reading
+	Msg      string   `json:"msg"`
+	Input    *Input   `json:"input,omitempty"`
+	Snapshot Snapshot `json:"snapshot"`
+}
+
+// Recording is a list of records, oldest first. Only the last Limit records
+// are kept whole. Older ones lose their screen, and are dropped unless
+// replaying needs them: the inputs, and the record before each input that
+// tells where the previous one led
+type Recording struct {
+	Records []Record `json:"records"`
+	// Limit is how many records Add keeps whole, none are trimmed if zero
+	Limit int `json:"-"`
+}
+
+// NewRecording creates an empty recording that keeps the last limit records
+// whole
+func NewRecording(limit int) Recording {
+	return Recording{Limit: limit}
+}
+
+// Add records msg and the snapshot taken after it, and trims the record
+// that no longer fits in the limit. It returns the index of that record if
+// it was dropped, -1 otherwise
+func (r *Recording) Add(msg tea.Msg, snapshot Snapshot) int {
+	summary := fmt.Sprintf("%+v", msg)
+	if key, ok := msg.(tea.KeyMsg); ok {
+		summary = key.String()
+	}
+	if runes := []rune(summary); len(runes) > maxSummary {
+		summary = string(runes[:maxSummary-1]) + "…"
+	}
+
+	r.Records = append(r.Records, Record{
+		Time:     time.Now(),
+		Type:     fmt.Sprintf("%T", msg),
+		Msg:      summary,
+		Input:    inputOf(msg),
+		Snapshot: snapshot,
+	})
+	if r.Limit <= 0 || len(r.Records) <= r.Limit {
+		return -1
+	}
+	old := len(r.Records) - r.Limit - 1
+	r.Records[old].Snapshot.View = ""
+	if r.Records[old].Input != nil || r.Records[old+1].Input != nil {
+		return -1
+	}
+	r.Records = slices.Delete(r.Records, old, old+1)
+	return old
+}
+
+// Inputs returns the indexes of the records that hold inputs
+func (r Recording) Inputs() []int {
+	var inputs []int
+	for i, record := range r.Records {
+		if record.Input != nil {
+			inputs = append(inputs, i)
+		}
+	}
+	return inputs
+}
+
+// RecordingPath returns a new file name in the recordings folder of the
+// config dir
+func RecordingPath() string {
+	name := "recording-" + time.Now().Format("20060102-150405") + ".json"
+	return filepath.Join(config.GetConfigPath(), "recordings", name)
+}
+
+// Save writes the recording to the file at path as JSON
+func (r Recording) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// LoadRecording reads a recording Save wrote
+func LoadRecording(path string) (Recording, error) {
+	var r Recording
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return r, err
+	}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return r, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return r, nil
+}
@@ -0,0 +1,61 @@
+// app/debug/recording_test.go
+package debug
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tickMsg stands for the messages that follow from inputs
+type tickMsg int
+
+func TestRecordingKeepsInputs(t *testing.T) {
+	msgs := []tea.Msg{
+		tea.WindowSizeMsg{Width: 80, Height: 24},
+		tickMsg(1), tickMsg(2),
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")},
+		tickMsg(3), tickMsg(4), tickMsg(5), tickMsg(6),
+		tea.KeyMsg{Type: tea.KeyEnter},
+		tickMsg(7), tickMsg(8), tickMsg(9),
+	}
+	r := NewRecording(3)
+	for i, msg := range msgs {
+		r.Add(msg, Snapshot{Page: fmt.Sprint(i), View: fmt.Sprintf("screen %d", i)})
+	}
+
+	// The inputs, the record before each, and the last three stay
+	var pages []string
+	for _, record := range r.Records {
+		pages = append(pages, record.Snapshot.Page)
+	}
+	if want := []string{"0", "2", "3", "7", "8", "9", "10", "11"}; !slices.Equal(pages, want) {
+		t.Fatalf("kept the records after messages %v, want %v", pages, want)
+	}
+	if got := len(r.Inputs()); got != 3 {
+		t.Errorf("kept %d inputs, want 3", got)
+	}
+	for i, record := range r.Records {
+		if whole := i >= len(r.Records)-3; whole != (record.Snapshot.View != "") {
+			t.Errorf("record %d has screen %q", i, record.Snapshot.View)
+		}
+	}
+}
+
+func TestRecordingAddReportsDropped(t *testing.T) {
+	r := NewRecording(2)
+	r.Add(tickMsg(1), Snapshot{})
+	r.Add(tickMsg(2), Snapshot{})
+	if dropped := r.Add(tickMsg(3), Snapshot{}); dropped != 0 {
+		t.Errorf("dropped %d, want the first record", dropped)
+	}
+	r.Add(tea.KeyMsg{Type: tea.KeyEnter}, Snapshot{})
+	if dropped := r.Add(tickMsg(4), Snapshot{}); dropped != -1 {
+		t.Errorf("dropped %d, the record before an input has to stay", dropped)
+	}
+	if dropped := r.Add(tickMsg(5), Snapshot{}); dropped != -1 {
+		t.Errorf("dropped input %d", dropped)
+	}
+}
@@ -1,16 +1,47 @@
 package global
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"bubbletea-app/app/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 type SpawnModalMsg struct {
 	Title       string
 	Description string
 	OnConfirm   func() tea.Msg
 	OnCancel    func() tea.Msg
+	// Prompt asks for a line of text, filled in with Value. Confirming
+	// passes it to OnSubmit instead of calling OnConfirm
+	Prompt   bool
+	Value    string
+	OnSubmit func(value string) tea.Msg
 }
 
 type KillModalMsg bool
 
 type (
 	InputFocusChangedMsg bool
+	// PageFocusChangedMsg is sent to a page when it is shown (true) or left (false)
+	PageFocusChangedMsg bool
+	// PageVisibleMsg is sent to a page when the app switches to it (true) or
+	// away from it (false). Split panes pass it to both panes, which stay
+	// visible when only the focus moves between them
+	PageVisibleMsg bool
 )
+
+// SettingsChangedMsg is sent to every page after new settings were saved
+type SettingsChangedMsg struct {
+	Settings config.Settings
+}
+
+// SearchMsg asks the current page to start searching its content. It is
+// only sent to pages that are Searchers and can search right now
+type SearchMsg struct{}
+
+// Searcher is a page that can search its content. Searchable reports
+// whether it can at the moment, pages that can't leave the search key to
+// their content
+type Searcher interface {
+	Searchable() bool
+}
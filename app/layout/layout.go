@@ -0,0 +1,231 @@
+// app/layout/layout.go
+package layout
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Direction is the main axis regions are stacked along
+type Direction int
+
+const (
+	// Vertical stacks regions top to bottom (a column)
+	Vertical Direction = iota
+	// Horizontal places regions left to right (a row)
+	Horizontal
+)
+
+// Rect is the area a region was given, relative to its container
+type Rect struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// Region is a named slot in a flex container. Fixed regions always get their
+// size; flexible regions share what is left by weight, within Min and Max
+type Region struct {
+	Name   string
+	Size   int // fixed size along the main axis, used when Weight is 0
+	Weight int
+	Min    int
+	Max    int // 0 means no maximum
+}
+
+// Fixed returns a region that is always size cells long
+func Fixed(name string, size int) Region {
+	return Region{Name: name, Size: size}
+}
+
+// Flexible returns a region that takes a weighted share of the free space
+func Flexible(name string, weight int) Region {
+	return Region{Name: name, Weight: weight}
+}
+
+// WithMin sets the smallest size a flexible region shrinks to
+func (r Region) WithMin(size int) Region {
+	r.Min = size
+	return r
+}
+
+// WithMax sets the largest size a flexible region grows to
+func (r Region) WithMax(size int) Region {
+	r.Max = size
+	return r
+}
+
+// Flex lays out regions along one axis, like a CSS flexbox
+type Flex struct {
+	Direction Direction
+	Regions   []Region
+	Gap       int
+}
+
+// Column stacks regions vertically
+func Column(regions ...Region) Flex {
+	return Flex{Direction: Vertical, Regions: regions}
+}
+
+// Row places regions side by side
+func Row(regions ...Region) Flex {
+	return Flex{Direction: Horizontal, Regions: regions}
+}
+
+// WithGap sets the number of empty cells between regions
+func (f Flex) WithGap(gap int) Flex {
+	f.Gap = gap
+	return f
+}
+
+// Sizes splits total cells along the main axis between the regions
+func (f Flex) Sizes(total int) []int {
+	sizes := make([]int, len(f.Regions))
+	if len(f.Regions) == 0 {
+		return sizes
+	}
+
+	free := total - f.Gap*(len(f.Regions)-1)
+	var flexible []int
+	for i, r := range f.Regions {
+		if r.Weight > 0 {
+			flexible = append(flexible, i)
+			continue
+		}
+		sizes[i] = r.Size
+		free -= r.Size
+	}
+
+	// Share the free space by weight. Regions that hit their min or max are
+	// pinned there and the rest is shared again between the others
+	for len(flexible) > 0 {
+		weights := 0
+		for _, i := range flexible {
+			weights += f.Regions[i].Weight
+		}
+
+		space := max(free, 0)
+		var pinned []int
+		for _, i := range flexible {
+			r := f.Regions[i]
+			share := space * r.Weight / weights
+			if share < r.Min {
+				sizes[i] = r.Min
+				pinned = append(pinned, i)
+			} else if r.Max > 0 && share > r.Max {
+				sizes[i] = r.Max
+				pinned = append(pinned, i)
+			}
+		}
+
+		if len(pinned) == 0 {
+			given := 0
+			for _, i := range flexible {
+				sizes[i] = space * f.Regions[i].Weight / weights
+				given += sizes[i]
+			}
+			// Hand out the cells lost to rounding, one each from the front
+			for n := 0; given < space && n < len(flexible); n++ {
+				sizes[flexible[n]]++
+				given++
+			}
+			free -= given
+			break
+		}
+
+		for _, i := range pinned {
+			free -= sizes[i]
+		}
+		flexible = without(flexible, pinned)
+	}
+
+	// Not enough room: take the overflow back from the last regions first
+	for i := len(sizes) - 1; i >= 0 && free < 0; i-- {
+		take := min(sizes[i], -free)
+		sizes[i] -= take
+		free += take
+	}
+	return sizes
+}
+
+// Layout returns the rectangle of every region for a container of the given
+// size, keyed by region name
+func (f Flex) Layout(width, height int) map[string]Rect {
+	rects := make(map[string]Rect, len(f.Regions))
+	total := height
+	if f.Direction == Horizontal {
+		total = width
+	}
+
+	offset := 0
+	for i, size := range f.Sizes(total) {
+		r := Rect{X: 0, Y: offset, Width: width, Height: size}
+		if f.Direction == Horizontal {
+			r = Rect{X: offset, Y: 0, Width: size, Height: height}
+		}
+		rects[f.Regions[i].Name] = r
+		offset += size + f.Gap
+	}
+	return rects
+}
+
+// Render places each region's view into its rectangle and joins them.
+// Views are padded or cut to fit exactly, and regions without a view are
+// left blank
+func (f Flex) Render(width, height int, views map[string]string) string {
+	rects := f.Layout(width, height)
+
+	var parts []string
+	for i, region := range f.Regions {
+		r := rects[region.Name]
+		if i > 0 && f.Gap > 0 {
+			parts = append(parts, gap(f.Direction, f.Gap, width, height))
+		}
+		if r.Width <= 0 || r.Height <= 0 {
+			continue
+		}
+		parts = append(parts, Fit(views[region.Name], r.Width, r.Height))
+	}
+
+	if f.Direction == Horizontal {
+		return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
+// Fit pads or truncates view so it is exactly width by height cells
+func Fit(view string, width, height int) string {
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().
+		Width(width).
+		Height(height).
+		MaxWidth(width).
+		MaxHeight(height).
+		Render(view)
+}
+
+func gap(dir Direction, size, width, height int) string {
+	if dir == Horizontal {
+		return Fit("", size, height)
+	}
+	return Fit("", width, size)
+}
+
+func without(indexes, remove []int) []int {
+	var kept []int
+	for _, i := range indexes {
+		drop := false
+		for _, r := range remove {
+			if i == r {
+				drop = true
+				break
+			}
+		}
+		if !drop {
+			kept = append(kept, i)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,106 @@
+// app/layout/layout_test.go
+package layout
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSizes(t *testing.T) {
+	tests := []struct {
+		name  string
+		flex  Flex
+		total int
+		want  []int
+	}{
+		{"empty", Row(), 10, []int{}},
+		{"fixed and flexible", Column(Fixed("header", 3), Flexible("content", 1), Fixed("footer", 2)), 20, []int{3, 15, 2}},
+		{"weights", Row(Flexible("a", 2), Flexible("b", 1)), 9, []int{6, 3}},
+		{"rounding goes to the front", Row(Flexible("a", 1), Flexible("b", 1), Flexible("c", 1)), 10, []int{4, 3, 3}},
+		{"gap", Row(Flexible("a", 1), Flexible("b", 1)).WithGap(2), 10, []int{4, 4}},
+		{"min", Row(Flexible("a", 1).WithMin(8), Flexible("b", 3)), 20, []int{8, 12}},
+		{"max", Row(Flexible("a", 1).WithMax(4), Flexible("b", 1)), 20, []int{4, 16}},
+		{"min and max", Row(Flexible("a", 1).WithMax(3), Flexible("b", 1).WithMin(12), Flexible("c", 1)), 20, []int{3, 12, 5}},
+		{"overflow comes off the end", Column(Fixed("header", 5), Flexible("content", 1).WithMin(4), Fixed("footer", 5)), 10, []int{5, 4, 1}},
+		{"nothing left", Column(Fixed("header", 5), Fixed("footer", 5)), 3, []int{3, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.flex.Sizes(tt.total); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Sizes(%d) = %v, want %v", tt.total, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLayout(t *testing.T) {
+	tests := []struct {
+		name          string
+		flex          Flex
+		width, height int
+		want          map[string]Rect
+	}{
+		{
+			"row with gap",
+			Row(Fixed("nav", 10), Flexible("main", 1)).WithGap(1), 40, 5,
+			map[string]Rect{"nav": {0, 0, 10, 5}, "main": {11, 0, 29, 5}},
+		},
+		{
+			"column",
+			Column(Fixed("header", 2), Flexible("content", 1)), 30, 12,
+			map[string]Rect{"header": {0, 0, 30, 2}, "content": {0, 2, 30, 10}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.flex.Layout(tt.width, tt.height); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Layout(%d, %d) = %v, want %v", tt.width, tt.height, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResponsivePick(t *testing.T) {
+	responsive := NewResponsive(Row(Flexible("wide", 1)),
+		Breakpoint{MaxWidth: 40, Flex: Column(Flexible("narrow", 1))},
+		Breakpoint{MaxWidth: 70, MaxHeight: 20, Flex: Column(Flexible("short", 1))},
+	)
+	tests := []struct {
+		width, height int
+		want          string
+	}{
+		{40, 30, "narrow"},
+		{41, 30, "wide"},
+		{41, 20, "short"},
+		{70, 20, "short"},
+		{71, 20, "wide"},
+		{70, 21, "wide"},
+		{30, 10, "narrow"},
+	}
+	for _, tt := range tests {
+		if got := responsive.Pick(tt.width, tt.height).Regions[0].Name; got != tt.want {
+			t.Errorf("Pick(%d, %d) = %s, want %s", tt.width, tt.height, got, tt.want)
+		}
+	}
+}
+
+func TestSizeLimits(t *testing.T) {
+	tests := []struct {
+		width, height     int
+		tooSmall, compact bool
+	}{
+		{MinWidth - 1, MinHeight, true, true},
+		{MinWidth, MinHeight - 1, true, true},
+		{MinWidth, MinHeight, false, true},
+		{CompactWidth, 24, false, true},
+		{CompactWidth + 1, 24, false, false},
+	}
+	for _, tt := range tests {
+		if got := TooSmall(tt.width, tt.height); got != tt.tooSmall {
+			t.Errorf("TooSmall(%d, %d) = %t", tt.width, tt.height, got)
+		}
+		if got := Compact(tt.width); got != tt.compact {
+			t.Errorf("Compact(%d) = %t", tt.width, got)
+		}
+	}
+}
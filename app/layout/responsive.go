@@ -0,0 +1,72 @@
+// app/layout/responsive.go
+package layout
+
+// Breakpoint swaps in a different layout once the terminal is at most
+// MaxWidth columns wide or MaxHeight rows tall. Zero means no limit
+type Breakpoint struct {
+	MaxWidth  int
+	MaxHeight int
+	Flex      Flex
+}
+
+// Matches reports whether a terminal of the given size falls in the breakpoint
+func (b Breakpoint) Matches(width, height int) bool {
+	if b.MaxWidth > 0 && width > b.MaxWidth {
+		return false
+	}
+	if b.MaxHeight > 0 && height > b.MaxHeight {
+		return false
+	}
+	return true
+}
+
+// Responsive picks a layout based on the terminal size
+type Responsive struct {
+	Default     Flex
+	Breakpoints []Breakpoint
+}
+
+// NewResponsive creates a responsive layout that uses flex unless one of the
+// breakpoints matches
+func NewResponsive(flex Flex, breakpoints ...Breakpoint) Responsive {
+	return Responsive{Default: flex, Breakpoints: breakpoints}
+}
+
+// Pick returns the layout for the given size. The first matching breakpoint
+// wins, so list the smallest ones first
+func (r Responsive) Pick(width, height int) Flex {
+	for _, b := range r.Breakpoints {
+		if b.Matches(width, height) {
+			return b.Flex
+		}
+	}
+	return r.Default
+}
+
+// Layout returns the region rectangles of the picked layout
+func (r Responsive) Layout(width, height int) map[string]Rect {
+	return r.Pick(width, height).Layout(width, height)
+}
+
+// Render renders views with the picked layout
+func (r Responsive) Render(width, height int, views map[string]string) string {
+	return r.Pick(width, height).Render(width, height, views)
+}
+
+const (
+	// MinWidth and MinHeight are the smallest terminal size the app renders in
+	MinWidth  = 40
+	MinHeight = 12
+	// CompactWidth is the width at or below which compact layouts are used
+	CompactWidth = 70
+)
+
+// TooSmall reports whether the terminal is below the minimum size
+func TooSmall(width, height int) bool {
+	return width < MinWidth || height < MinHeight
+}
+
+// Compact reports whether compact layouts should be used at this width
+func Compact(width int) bool {
+	return width <= CompactWidth
+}
@@ -2,57 +2,97 @@ package pages
 
 import (
 	"bubbletea-app/app/components"
-	"fmt"
+	"bubbletea-app/app/config"
+	"bubbletea-app/app/global"
+	"bubbletea-app/app/layout"
+	_ "embed"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 )
 
+// aboutText is the content of the About page
+//
+//go:embed about.md
+var aboutText string
+
 type AboutModel struct {
-	header components.HeaderModel
-	footer components.FooterModel
-	width  int
-	height int
+	header    components.HeaderModel
+	footer    components.FooterModel
+	markdown  components.MarkdownModel
+	textFocus *components.FocusRegion
+	nav       *config.NavigationManager
+	width     int
+	height    int
 }
 
-func NewAboutModel() AboutModel {
+func NewAboutModel(keyMap config.KeyMap) AboutModel {
+	textFocus := components.NewFocusRegion("text")
+	nav := config.NewNavigationManager()
+	nav.AddItem(textFocus)
+	nav.Focus()
+
 	return AboutModel{
-		header: components.NewHeaderModel("About"),
-		footer: components.NewFooterModel(),
+		header:    components.NewHeaderModel("About"),
+		footer:    components.NewFooterModel(),
+		markdown:  components.NewMarkdownModel(aboutText, keyMap),
+		textFocus: textFocus,
+		nav:       nav,
 	}
 }
 
+// Searchable reports whether the text has focus, see global.Searcher
+func (m AboutModel) Searchable() bool {
+	return m.textFocus.IsFocused() && !m.markdown.Finding()
+}
+
 func (m AboutModel) Init() tea.Cmd {
 	return nil
 }
 
 func (m AboutModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		content := m.layout().Layout(m.width, m.height)["content"]
+		m.markdown.SetSize(content.Width, content.Height)
+
+	case global.PageFocusChangedMsg:
+		if msg {
+			m.nav.Focus()
+		} else {
+			m.nav.Blur()
+		}
+
+	case global.SearchMsg:
+		if m.Searchable() {
+			m.markdown, cmd = m.markdown.StartFind()
+		}
+
+	case tea.KeyMsg:
+		// The text only scrolls with keys while it has focus
+		if m.textFocus.IsFocused() {
+			m.markdown, cmd = m.markdown.Update(msg)
+		}
+
+	case tea.MouseMsg:
+		m.markdown, cmd = m.markdown.Update(msg)
 	}
-	return m, nil
+	return m, cmd
+}
+
+func (m AboutModel) layout() layout.Responsive {
+	return pageLayout(m.header.View(m.width), m.footer.View(m.width))
 }
 
 func (m AboutModel) View() string {
 	headerView := m.header.View(m.width)
 	footerView := m.footer.View(m.width)
 
-	contentHeight := m.height - lipgloss.Height(headerView) - lipgloss.Height(footerView) - 2
-	aboutText := lipgloss.NewStyle().
-		Width(m.width - 2).
-		Height(contentHeight). // SET HEIGHT FOR CONTENT
-		Padding(1).
-		Render("a nice starting point for a bubbletea terminal app")
-	content := fmt.Sprintf(
-		"%s\n%s\n%s",
-		headerView,
-		aboutText,
-		footerView,
-	)
-
-	contentContainer := lipgloss.NewStyle().
-		Height(contentHeight).Render(content)
-	return contentContainer
+	return m.layout().Render(m.width, m.height, map[string]string{
+		"header":  headerView,
+		"content": m.markdown.View(),
+		"footer":  footerView,
+	})
 }
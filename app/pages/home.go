@@ -4,90 +4,674 @@ import (
 	"bubbletea-app/app/actions"
 	"bubbletea-app/app/components"
 	"bubbletea-app/app/config"
+	"bubbletea-app/app/global"
+	"bubbletea-app/app/layout"
 	"bubbletea-app/app/styles"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+// loadState tracks how far Home got fetching its data
+type loadState int
+
+const (
+	stateLoading loadState = iota
+	stateLoaded
+	stateFailed
 )
 
 type HomeModel struct {
-	list   list.Model
-	header components.HeaderModel
-	footer components.FooterModel
-	width  int
-	height int
+	id          string
+	list        list.Model
+	listFocus   *components.FocusRegion
+	nav         *config.NavigationManager
+	header      components.HeaderModel
+	footer      components.FooterModel
+	spinner     spinner.Model
+	retry       components.ButtonModel
+	form        components.FormModel
+	items       []item
+	search      textinput.Model
+	searching   bool
+	query       string
+	saved       []string
+	savedIndex  int
+	sort        sortMode
+	detail      ItemDetailModel
+	detailOpen  bool
+	preview     ImportPreviewModel
+	previewOpen bool
+	keyMap      config.KeyMap
+	store       *actions.Store
+	state       loadState
+	err         error
+	loaded      int
+	total       int
+	loadingMore bool
+	moreOffset  int
+	offline     bool
+	fetchCtx    context.Context
+	cancelFetch context.CancelFunc
+	interrupted bool
+	lastRefresh time.Time
+	scheduler   components.SchedulerModel
+	refreshing  bool
+	changeSeq   int
+	lastCopy    copyState
+	announced   ItemSelectedMsg
+	notice      components.NotificationModel
+	width       int
+	height      int
 }
 
+// refreshMsg asks Home to fetch its data again
+type refreshMsg struct{}
+
 type item struct {
-	title, desc string
+	id, title, desc string
+	status          string
+	created         time.Time
+	selected        bool
+	// Changed by the last refresh, highlighted for a while
+	changed bool
+	// Rune indexes that matched the search query
+	titleMatches, descMatches []int
 }
 
 func (i item) Title() string       { return i.title }
 func (i item) Description() string { return i.desc }
-func (i item) FilterValue() string { return i.title }
+func (i item) FilterValue() string { return i.title + " " + i.desc }
 
-func NewHomeModel(keyMap config.KeyMap) HomeModel {
-	// Create example items
-	items := []list.Item{
-		item{title: "Task 1", desc: "Description for task 1"},
-		item{title: "Task 2", desc: "Description for task 2"},
-		item{title: "Task 3", desc: "Description for task 3"},
+// itemDelegate renders items like the default delegate, plus search
+// matches, selection, status and group headers. Every rendered item is
+// marked as a mouse zone, so clicks can be mapped back to list indexes
+type itemDelegate struct {
+	list.DefaultDelegate
+	prefix string
+	// group returns the header an item is listed under, nil for no headers
+	group func(item) string
+}
+
+// Height makes room for a group header above every item. Items that don't
+// start a group use the line as spacing instead
+func (d itemDelegate) Height() int {
+	if d.group != nil {
+		return d.DefaultDelegate.Height() + d.DefaultDelegate.Spacing()
 	}
+	return d.DefaultDelegate.Height()
+}
+
+func (d itemDelegate) Spacing() int {
+	if d.group != nil {
+		return 0
+	}
+	return d.DefaultDelegate.Spacing()
+}
+
+func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	it, ok := listItem.(item)
+	if !ok || m.Width() <= 0 {
+		return
+	}
+
+	var b strings.Builder
+	if d.group != nil {
+		fmt.Fprintln(&b, d.header(m, index, it))
+	}
+	d.renderItem(&b, m, index, it)
+	fmt.Fprint(w, zone.Mark(itemZoneID(d.prefix, index), b.String()))
+}
+
+// header returns the group name when it differs from the previous item or
+// the item is the first on its page, and an empty line otherwise
+func (d itemDelegate) header(m list.Model, index int, it item) string {
+	group := d.group(it)
+	if index != m.Paginator.Page*m.Paginator.PerPage && index > 0 {
+		if prev, ok := m.VisibleItems()[index-1].(item); ok && d.group(prev) == group {
+			return ""
+		}
+	}
+	return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#25A065")).PaddingLeft(2).Render(group)
+}
+
+// renderItem renders it like the default delegate does, with the runes that
+// matched the search highlighted, a mark on selected items and the status
+// after the title
+func (d itemDelegate) renderItem(w io.Writer, m list.Model, index int, it item) {
+	s := d.Styles
+	titleStyle, descStyle := s.NormalTitle, s.NormalDesc
+	if index == m.Index() {
+		titleStyle, descStyle = s.SelectedTitle, s.SelectedDesc
+	}
+
+	mark := ""
+	if it.selected {
+		mark = lipgloss.NewStyle().Foreground(lipgloss.Color("#25A065")).Render("✓ ")
+	}
+	if it.changed {
+		titleStyle = titleStyle.Foreground(lipgloss.Color("#FFB86C"))
+		descStyle = descStyle.Foreground(lipgloss.Color("#FFB86C"))
+	}
+	tag := "  " + lipgloss.NewStyle().Foreground(lipgloss.Color("#666666")).Render(it.data().ItemStatus())
+
+	textwidth := m.Width() - s.NormalTitle.GetPaddingLeft() - s.NormalTitle.GetPaddingRight()
+	title := ansi.Truncate(it.title, max(textwidth-lipgloss.Width(mark+tag), 1), "…")
+	title = mark + highlight(title, it.titleMatches, titleStyle, s.FilterMatch) + tag
+	fmt.Fprint(w, titleStyle.Render(title))
+
+	if d.ShowDescription {
+		desc, _, _ := strings.Cut(it.desc, "\n")
+		desc = ansi.Truncate(desc, textwidth, "…")
+		fmt.Fprint(w, "\n"+descStyle.Render(highlight(desc, it.descMatches, descStyle, s.FilterMatch)))
+	}
+}
+
+func itemZoneID(prefix string, index int) string {
+	return fmt.Sprintf("%sitem-%d", prefix, index)
+}
 
+// NewHomeModel creates the Home page. Items come from store, which may be
+// shared with other pages
+func NewHomeModel(keyMap config.KeyMap, store *actions.Store) HomeModel {
 	// Setup list
-	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	id := zone.NewPrefix()
+	l := list.New(nil, itemDelegate{DefaultDelegate: list.NewDefaultDelegate(), prefix: id}, 0, 0)
 	l.Title = "Home Page"
 	l.SetShowStatusBar(false)
+	// Home filters itself, over the description too, see applyFilter
 	l.SetFilteringEnabled(false)
 	l.SetShowHelp(false)
 	l.SetShowTitle(false)
 	l.Styles.Title = styles.TitleStyle
+	// esc clears the selection and the filter, quitting is up to the app
+	l.DisableQuitKeybindings()
+	// d deletes and u undoes, so they can't page the list
+	l.KeyMap.NextPage.SetKeys("right", "l", "pgdown", "f")
+	l.KeyMap.PrevPage.SetKeys("left", "h", "pgup", "b")
 
-	// Initialize with data from API
-	apiData := actions.FetchData()
-	for _, d := range apiData {
-		l.InsertItem(len(items), item{title: d.Title, desc: d.Description})
+	listFocus := components.NewFocusRegion("list")
+	nav := config.NewNavigationManager()
+	nav.AddItem(listFocus)
+	nav.Focus()
+
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#25A065"))
+
+	retry := components.NewButtonModel("Retry", func() tea.Msg {
+		return refreshMsg{}
+	})
+	retry.Focus()
+
+	saved, _ := config.LoadSavedFilters()
+
+	m := HomeModel{
+		id:         id,
+		list:       l,
+		listFocus:  listFocus,
+		nav:        nav,
+		header:     components.NewHeaderModel("Home"),
+		footer:     components.NewFooterModel(),
+		spinner:    s,
+		retry:      retry,
+		search:     newSearchInput(keyMap),
+		saved:      saved,
+		savedIndex: -1,
+		notice:     components.NewNotificationModel(),
+		keyMap:     keyMap,
+		store:      store,
 	}
+	// Pages start hidden. Fetches wait until the page is shown and are
+	// canceled when it is left, see setVisible
+	m.fetchCtx, m.cancelFetch = context.WithCancel(context.Background())
+	m.cancelFetch()
+	m.scheduler = components.NewSchedulerModel(store.Settings().RefreshEvery())
+	if _, err := store.Source(); err != nil {
+		m.state = stateFailed
+		m.err = err
+	} else {
+		m.interrupted = true
+	}
+	return m
+}
 
-	return HomeModel{
-		list:   l,
-		header: components.NewHeaderModel("Home"),
-		footer: components.NewFooterModel(),
+// listItems converts API data into list items
+func listItems(data []actions.DataItem) []item {
+	items := make([]item, len(data))
+	for i, d := range data {
+		items[i] = toItem(d)
 	}
+	return items
 }
 
+// Init starts the refresh schedule. The data is fetched once the page is
+// shown, so the UI shows up right away and hidden pages take what the shown
+// ones fetch
 func (m HomeModel) Init() tea.Cmd {
-	return nil
+	return m.scheduler.Init()
+}
+
+// Searchable reports whether the list has focus, the detail view and the
+// import preview have nothing to search
+func (m HomeModel) Searchable() bool {
+	return m.listFocus.IsFocused()
 }
 
+// refresh fetches the data again, unless a fetch is already running. The
+// cache is skipped, unless the backend can't be reached
+func (m HomeModel) refresh() (HomeModel, tea.Cmd) {
+	if m.state == stateLoading {
+		return m, nil
+	}
+	m.refreshing = true
+	return m.load(actions.RefreshItemsCmd)
+}
+
+// load starts fetching the data with fetch and shows the spinner meanwhile.
+// A page that isn't shown waits until it is
+func (m HomeModel) load(fetch func(context.Context, *actions.Store) tea.Cmd) (HomeModel, tea.Cmd) {
+	m.state = stateLoading
+	m.err = nil
+	if m.fetchCtx.Err() != nil {
+		m.interrupted = true
+		return m, nil
+	}
+	return m, tea.Batch(m.spinner.Tick, fetch(m.fetchCtx, m.store))
+}
+
+// setVisible cancels the running fetches when the page is left, and starts
+// the interrupted ones over when it is shown again
+func (m HomeModel) setVisible(visible bool) (HomeModel, tea.Cmd) {
+	if !visible {
+		m.cancelFetch()
+		m.interrupted = m.interrupted || m.state == stateLoading
+		m.loadingMore = false
+		m.refreshing = m.refreshing && m.interrupted
+		return m, nil
+	}
+
+	if m.fetchCtx.Err() != nil {
+		m.fetchCtx, m.cancelFetch = context.WithCancel(context.Background())
+	}
+	if !m.interrupted {
+		return m.refreshOverdue()
+	}
+	m.interrupted = false
+	return m.load(actions.LoadItemsCmd)
+}
+
+// Update handles msg, and tells panels following the list when that moved
+// the cursor to another item
 func (m HomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	home := model.(HomeModel)
+	return home, tea.Batch(cmd, home.announceSelection())
+}
+
+func (m HomeModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		m.list.SetSize(msg.Width-2, msg.Height-7)
+		width, height := m.bodySize()
+		m.list.SetSize(m.listSize())
+		m.form.SetWidth(min(width, formWidth))
+		// Leave room for the prompt and the match count
+		m.search.Width = max(width-16, 1)
+		if m.detailOpen {
+			m.detail.SetSize(width, height)
+		}
+		if m.previewOpen {
+			m.preview.SetSize(width, height)
+		}
+		return m, nil
+
+	case tea.MouseMsg:
+		if m.form.IsOpen() {
+			return m.updateForm(msg)
+		}
+		if m.detailOpen {
+			var cmd tea.Cmd
+			m.detail, cmd = m.detail.Update(msg)
+			return m, cmd
+		}
+		if m.previewOpen {
+			var cmd tea.Cmd
+			m.preview, cmd = m.preview.Update(msg)
+			return m, cmd
+		}
+		if m.state == stateFailed {
+			var cmd tea.Cmd
+			m.retry, cmd = m.retry.Update(msg)
+			return m, cmd
+		}
+		return m.handleMouse(msg).loadMore()
+
+	case spinner.TickMsg:
+		// Let the tick chain die once loading is over
+		if m.state != stateLoading && !m.loadingMore {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case refreshMsg:
+		return m.refresh()
+
+	case global.SettingsChangedMsg:
+		m.store.Reopen(msg.Settings)
+		tick := m.scheduler.SetInterval(msg.Settings.RefreshEvery())
+		m.refreshing = false
+		m, cmd := m.load(actions.LoadItemsCmd)
+		return m, tea.Batch(cmd, tick)
+
+	case components.SchedulerTickMsg:
+		return m.autoRefresh(msg)
+
+	case highlightExpiredMsg:
+		return m.clearHighlight(msg)
+
+	case mutateMsg:
+		if msg.origin != m.id {
+			return m, nil
+		}
+		return m.mutate(msg.mutation)
+
+	case actions.MutationDoneMsg:
+		return m.mutationDone(msg)
+
+	case actions.MutationFailedMsg:
+		return m.mutationFailed(msg)
+
+	case actions.ExportDoneMsg:
+		return m.exportDone(msg)
+
+	case actions.CopiedMsg:
+		return m.copied(msg)
+
+	case detailActionMsg:
+		return m.handleDetailAction(msg)
+
+	case actions.ImportPreviewMsg:
+		return m.openPreview(msg)
+
+	case importActionMsg:
+		return m.handleImportAction(msg)
+
+	case actions.ImportDoneMsg:
+		return m.importDone(msg)
+
+	case components.NotificationExpiredMsg:
+		m.notice, _ = m.notice.Update(msg)
 		return m, nil
+
+	case global.SearchMsg:
+		if !m.Searchable() {
+			return m, nil
+		}
+		return m.startSearch()
+
+	case filtersSavedMsg:
+		if msg.err != nil {
+			return m, m.notice.ShowError(fmt.Sprintf("Could not save filters: %v", msg.err))
+		}
+		return m, nil
+
+	case actions.DataLoadedMsg:
+		return m.pageLoaded(msg)
+
+	case actions.StreamEventMsg:
+		return m.streamEvent(msg)
+
+	case actions.DataErrorMsg:
+		// Fetches canceled by leaving the page start over on return
+		if errors.Is(msg.Err, context.Canceled) {
+			return m, nil
+		}
+		if msg.Offset > 0 {
+			return m.pageFailed(msg)
+		}
+		return m.loadFailed(msg)
+
+	case global.PageVisibleMsg:
+		return m.setVisible(bool(msg))
+
+	case global.PageFocusChangedMsg:
+		if msg {
+			m.nav.Focus()
+		} else {
+			m.nav.Blur()
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		// The list only reacts to keys while it has focus
+		if !m.listFocus.IsFocused() {
+			return m, nil
+		}
+		// Copying the same item again only goes on to its next part right
+		// after copying it
+		if !key.Matches(msg, m.keyMap.Copy) {
+			m.lastCopy = copyState{}
+		}
+		if m.form.IsOpen() {
+			return m.updateForm(msg)
+		}
+		if m.detailOpen {
+			var cmd tea.Cmd
+			m.detail, cmd = m.detail.Update(msg)
+			return m, cmd
+		}
+		if m.previewOpen {
+			var cmd tea.Cmd
+			m.preview, cmd = m.preview.Update(msg)
+			return m, cmd
+		}
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+		if key.Matches(msg, m.keyMap.Refresh) {
+			return m.refresh()
+		}
+		switch m.state {
+		case stateLoading:
+			return m, nil
+		case stateFailed:
+			if key.Matches(msg, m.keyMap.Enter) {
+				return m.refresh()
+			}
+			return m, nil
+		}
+		if model, cmd, ok := m.handleSelectionKey(msg); ok {
+			return model, cmd
+		}
+		if model, cmd, ok := m.handleItemKey(msg); ok {
+			return model, cmd
+		}
+		if m.query != "" {
+			switch {
+			case key.Matches(msg, m.keyMap.Esc):
+				m.query = ""
+				return m, m.applyFilter()
+			case key.Matches(msg, m.keyMap.SaveQuery):
+				return m.toggleSaved()
+			}
+		}
 	}
 
 	var cmd tea.Cmd
 	m.list, cmd = m.list.Update(msg)
-	return m, cmd
+	m, more := m.loadMore()
+	return m, tea.Batch(cmd, more)
 }
 
 func (m HomeModel) View() string {
-	headerView := m.header.View(m.width)
-	footerView := m.footer.View(m.width)
-	contentHeight := m.height - lipgloss.Height(headerView) - lipgloss.Height(footerView) - 2
-	content := fmt.Sprintf(
-		"%s\n%s\n%s",
-		headerView,
-		m.list.View(),
-		footerView,
-	)
-	contentContainer := lipgloss.NewStyle().
-		Height(contentHeight).Render(content)
-
-	return contentContainer
+	width, height := m.contentSize()
+	content := m.contentLayout().Render(width, height, map[string]string{
+		"status": m.statusView(),
+		"body":   m.bodyView(),
+	})
+
+	return m.layout().Render(m.width, m.height, map[string]string{
+		"header":  m.header.View(m.width),
+		"content": content,
+		"footer":  m.footer.View(m.width),
+	})
+}
+
+func (m HomeModel) layout() layout.Responsive {
+	return pageLayout(m.header.View(m.width), m.footer.View(m.width))
+}
+
+// contentLayout puts a status line above the list
+func (m HomeModel) contentLayout() layout.Flex {
+	return layout.Column(layout.Fixed("status", 1), layout.Flexible("body", 1))
+}
+
+func (m HomeModel) contentSize() (int, int) {
+	content := m.layout().Layout(m.width, m.height)["content"]
+	return content.Width, content.Height
+}
+
+// bodySize returns the space below the status line
+func (m HomeModel) bodySize() (int, int) {
+	body := m.contentLayout().Layout(m.contentSize())["body"]
+	return body.Width, body.Height
+}
+
+// statusView tells where the data comes from and how fresh it is, or what
+// the list is filtered by, plus the sort order and selection. A notification
+// about the last change takes its place for a while
+func (m HomeModel) statusView() string {
+	style := lipgloss.NewStyle().Padding(0, 1).Foreground(lipgloss.Color("#888888"))
+	if m.notice.Visible() {
+		return style.Render(m.notice.View())
+	}
+
+	var status string
+	switch {
+	case m.searching || m.query != "":
+		status = m.searchStatus()
+	case m.offline && m.state == stateLoaded:
+		// Stands out as a banner, the items may be out of date
+		style = style.Background(lipgloss.Color("#FF6700")).Foreground(lipgloss.Color("#000000"))
+		status = fmt.Sprintf("Offline — showing cached data from %s • %s", cachedTime(m.lastRefresh), m.countView())
+	default:
+		status = "Source: " + actions.SourceLabel(m.store.Settings())
+		if m.state == stateLoaded {
+			status += " • " + m.countView()
+		}
+		switch {
+		case m.state == stateLoading:
+			status += " • Loading…"
+		case !m.lastRefresh.IsZero():
+			status += " • Updated " + m.lastRefresh.Format("15:04:05")
+		}
+	}
+
+	status += m.healthView()
+	if m.sort != sortNone {
+		status += " • By " + m.sort.String()
+	}
+	if n := len(m.selection()); n > 0 {
+		status += fmt.Sprintf(" • %d selected", n)
+	}
+	return style.Render(status)
+}
+
+// healthView tells about retried requests and a paused API, if any
+func (m HomeModel) healthView() string {
+	health := m.store.Health()
+	switch {
+	case health.Circuit == actions.CircuitOpen:
+		return " • API paused until " + health.Reopens.Format("15:04:05")
+	case health.Circuit == actions.CircuitHalfOpen:
+		return " • API paused, the next request tries again"
+	case health.Retry > 0:
+		return fmt.Sprintf(" • Retrying %d/%d…", health.Retry, health.MaxRetries)
+	}
+	return ""
+}
+
+// bodyView shows the list, or what to do when there is nothing to list
+func (m HomeModel) bodyView() string {
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+
+	switch {
+	case m.form.IsOpen():
+		return lipgloss.NewStyle().Padding(1, 0).Render(m.form.View())
+
+	case m.detailOpen:
+		return m.detail.View()
+
+	case m.previewOpen:
+		return m.preview.View()
+
+	case m.state == stateLoading:
+		return fmt.Sprintf("\n %sLoading data…", m.spinner.View())
+
+	case m.state == stateFailed:
+		title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#F44336")).
+			Render("Could not load data")
+		message := lipgloss.NewStyle().Width(max(m.width-2, 1)).Render(m.err.Error())
+		return lipgloss.NewStyle().Padding(1, 1).Render(lipgloss.JoinVertical(
+			lipgloss.Left,
+			title,
+			message,
+			"",
+			m.retry.View(),
+			hint.Render("Press Enter or r to try again"),
+		))
+
+	case len(m.items) == 0:
+		return lipgloss.NewStyle().Padding(1, 1).Render(lipgloss.JoinVertical(
+			lipgloss.Left,
+			lipgloss.NewStyle().Bold(true).Render("Nothing here yet"),
+			hint.Render("The data source has no items. Press a to add one or r to check again"),
+		))
+
+	case len(m.list.Items()) == 0:
+		return lipgloss.NewStyle().Padding(1, 1).Render(lipgloss.JoinVertical(
+			lipgloss.Left,
+			lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("No items match '%s'", m.query)),
+			hint.Render("Try another search, or press esc to show all items"),
+		))
+	}
+
+	if m.hasMore() {
+		return lipgloss.JoinVertical(lipgloss.Left, m.list.View(), m.moreView())
+	}
+	return m.list.View()
+}
+
+// handleMouse scrolls the list with the wheel and selects clicked items
+func (m HomeModel) handleMouse(msg tea.MouseMsg) HomeModel {
+	switch {
+	case msg.Button == tea.MouseButtonWheelUp:
+		m.list.CursorUp()
+	case msg.Button == tea.MouseButtonWheelDown:
+		m.list.CursorDown()
+	case msg.Action == tea.MouseActionRelease && msg.Button == tea.MouseButtonLeft:
+		for i := range m.list.VisibleItems() {
+			if components.Clicked(itemZoneID(m.id, i), msg) {
+				m.list.Select(i)
+				break
+			}
+		}
+	}
+	return m
 }
@@ -0,0 +1,176 @@
+// app/pages/home_bulk.go
+package pages
+
+import (
+	"bubbletea-app/app/actions"
+	"bubbletea-app/app/global"
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleSelectionKey handles sorting, selecting and the actions that work on
+// the selection, or on the item under the cursor when nothing is selected.
+// It reports whether msg was one of them
+func (m HomeModel) handleSelectionKey(msg tea.KeyMsg) (HomeModel, tea.Cmd, bool) {
+	selection := m.selection()
+
+	switch {
+	case key.Matches(msg, m.keyMap.Sort):
+		model, cmd := m.cycleSort()
+		return model, cmd, true
+
+	case key.Matches(msg, m.keyMap.Select):
+		model, cmd := m.toggleSelected()
+		return model, cmd, true
+
+	case key.Matches(msg, m.keyMap.Export):
+		model, cmd := m.promptExport(selection)
+		return model, cmd, true
+
+	case len(selection) > 0 && key.Matches(msg, m.keyMap.Esc):
+		m.items = slices.Clone(m.items)
+		for i := range m.items {
+			m.items[i].selected = false
+		}
+		return m, m.applyFilter(), true
+
+	case len(selection) > 0 && key.Matches(msg, m.keyMap.Delete):
+		return m, m.confirmBulk(fmt.Sprintf("Delete %d items?", len(selection)), m.bulkDelete(selection)), true
+
+	case len(selection) > 0 && key.Matches(msg, m.keyMap.Status):
+		status := actions.NextStatus(selection[0].data().ItemStatus())
+		prompt := fmt.Sprintf("Mark %d items as %s?", len(selection), status)
+		return m, m.confirmBulk(prompt, m.bulkStatus(selection, status)), true
+
+	case key.Matches(msg, m.keyMap.Status):
+		selected, ok := m.list.SelectedItem().(item)
+		if !ok {
+			return m, nil, false
+		}
+		model, cmd := m.mutate(m.bulkStatus([]item{selected}, actions.NextStatus(selected.data().ItemStatus())))
+		return model, cmd, true
+	}
+	return m, nil, false
+}
+
+// selection returns the selected items in backend order
+func (m HomeModel) selection() []item {
+	var selected []item
+	for _, it := range m.items {
+		if it.selected {
+			selected = append(selected, it)
+		}
+	}
+	return selected
+}
+
+// toggleSelected selects or unselects the item under the cursor and moves on
+// to the next one
+func (m HomeModel) toggleSelected() (HomeModel, tea.Cmd) {
+	current, ok := m.list.SelectedItem().(item)
+	if !ok {
+		return m, nil
+	}
+	i := m.indexOf(current.id)
+	m.items = slices.Clone(m.items)
+	m.items[i].selected = !m.items[i].selected
+
+	cmd := m.applyFilter()
+	m.list.CursorDown()
+	m, more := m.loadMore()
+	return m, tea.Batch(cmd, more)
+}
+
+// bulkDelete returns one mutation that deletes items. The deletes go last
+// index first, so the indexes of the others stay valid and undo puts them
+// back in order
+func (m HomeModel) bulkDelete(items []item) actions.Mutation {
+	deletes := make([]actions.Mutation, len(items))
+	for i, it := range items {
+		deletes[i] = actions.DeleteItem(it.data(), m.indexOf(it.id))
+	}
+	slices.SortFunc(deletes, func(a, b actions.Mutation) int {
+		return cmp.Compare(b.Index, a.Index)
+	})
+	return actions.Batch(deletes...)
+}
+
+// bulkStatus returns one mutation that gives items status
+func (m HomeModel) bulkStatus(items []item, status string) actions.Mutation {
+	updates := make([]actions.Mutation, len(items))
+	for i, it := range items {
+		after := it.data()
+		after.Status = status
+		updates[i] = actions.UpdateItem(it.data(), after, m.indexOf(it.id))
+	}
+	if len(updates) == 1 {
+		return updates[0]
+	}
+	return actions.Batch(updates...)
+}
+
+// confirmBulk asks before applying mutation to the selection
+func (m HomeModel) confirmBulk(title string, mutation actions.Mutation) tea.Cmd {
+	return func() tea.Msg {
+		return global.SpawnModalMsg{
+			Title:       title,
+			Description: fmt.Sprintf("Press %s afterwards to undo.", m.keyMap.Undo.Help().Key),
+			OnConfirm: func() tea.Msg {
+				return mutateMsg{origin: m.id, mutation: mutation}
+			},
+		}
+	}
+}
+
+// promptExport asks where to export the selection, or all listed items in
+// their current order when nothing is selected. A file name picks the format
+// by its extension, a format name copies the items to the clipboard
+func (m HomeModel) promptExport(selection []item) (HomeModel, tea.Cmd) {
+	if len(selection) == 0 {
+		for _, listItem := range m.list.Items() {
+			if it, ok := listItem.(item); ok {
+				selection = append(selection, it)
+			}
+		}
+	}
+	if len(selection) == 0 {
+		return m, m.notice.Show("Nothing to export")
+	}
+
+	items := make([]actions.DataItem, len(selection))
+	for i, it := range selection {
+		items[i] = it.data()
+	}
+
+	return m, func() tea.Msg {
+		return global.SpawnModalMsg{
+			Title:       fmt.Sprintf("Export %d items", len(items)),
+			Description: "Save them to a .json, .csv or .md file, or type json, csv or md to copy them to the clipboard",
+			Prompt:      true,
+			Value:       actions.ExportPath(actions.FormatJSON),
+			OnSubmit: func(target string) tea.Msg {
+				return actions.ExportCmd(m.id, target, items)()
+			},
+		}
+	}
+}
+
+func (m HomeModel) exportDone(msg actions.ExportDoneMsg) (HomeModel, tea.Cmd) {
+	if msg.Origin != m.id {
+		return m, nil
+	}
+	if msg.Err != nil {
+		return m, m.notice.ShowError(fmt.Sprintf("Could not export: %v", msg.Err))
+	}
+	if msg.Path == "" && msg.ViaTerminal {
+		return m, m.notice.Show(copiedStatus(fmt.Sprintf("%d items as %s", msg.Count, msg.Format), true))
+	}
+	if msg.Path == "" {
+		return m, m.notice.Show(fmt.Sprintf("Copied %d items to the clipboard as %s", msg.Count, msg.Format))
+	}
+	return m, m.notice.Show(fmt.Sprintf("Exported %d items to %s", msg.Count, msg.Path))
+}
@@ -0,0 +1,86 @@
+// app/pages/home_copy.go
+package pages
+
+import (
+	"bubbletea-app/app/actions"
+	"encoding/json"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// copyTarget is the part of an item the copy key puts on the clipboard
+type copyTarget int
+
+const (
+	copyTitle copyTarget = iota
+	copyDescription
+	copyJSON
+)
+
+// copyState remembers what was copied last, so that pressing the copy key
+// again on the same item copies the next part of it
+type copyState struct {
+	id     string
+	target copyTarget
+}
+
+// next returns what to copy of d: the title first, then the description if
+// it has one, then the whole item as JSON
+func (c copyState) next(d actions.DataItem) copyTarget {
+	if c.id == "" || c.id != d.ID {
+		return copyTitle
+	}
+	target := (c.target + 1) % (copyJSON + 1)
+	if target == copyDescription && d.Description == "" {
+		target = copyJSON
+	}
+	return target
+}
+
+// copyItem copies the next part of d to the clipboard
+func (m HomeModel) copyItem(d actions.DataItem) (HomeModel, tea.Cmd) {
+	target := m.lastCopy.next(d)
+	m.lastCopy = copyState{id: d.ID, target: target}
+
+	again := m.keyMap.Copy.Help().Key
+	var what, text string
+	switch target {
+	case copyTitle:
+		what, text = fmt.Sprintf("the title of '%s'", d.Title), d.Title
+		if d.Description != "" {
+			what += fmt.Sprintf(" — press %s again for the description", again)
+		} else {
+			what += fmt.Sprintf(" — press %s again for JSON", again)
+		}
+	case copyDescription:
+		what, text = fmt.Sprintf("the description of '%s' — press %s again for JSON", d.Title, again), d.Description
+	case copyJSON:
+		data, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return m, m.notice.ShowError(fmt.Sprintf("Could not copy: %v", err))
+		}
+		what, text = fmt.Sprintf("'%s' as JSON", d.Title), string(data)
+	}
+	return m, actions.CopyCmd(m.id, what, text)
+}
+
+// copied confirms what the copy key put on the clipboard
+func (m HomeModel) copied(msg actions.CopiedMsg) (HomeModel, tea.Cmd) {
+	if msg.Origin != m.id {
+		return m, nil
+	}
+	if msg.Err != nil {
+		return m, m.notice.ShowError(fmt.Sprintf("Could not copy: %v", msg.Err))
+	}
+	return m, m.notice.Show(copiedStatus(msg.What, msg.ViaTerminal))
+}
+
+// copiedStatus tells what was copied. The terminal doesn't confirm OSC52,
+// so when it did the copying the status only says it was asked to
+func copiedStatus(what string, viaTerminal bool) string {
+	if viaTerminal {
+		return "Sent " + what + " to the terminal (OSC52), the copy could not be confirmed"
+	}
+	return "Copied " + what
+}
@@ -0,0 +1,52 @@
+// app/pages/home_detail.go
+package pages
+
+import (
+	"bubbletea-app/app/actions"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openDetail shows d in place of the list
+func (m HomeModel) openDetail(d actions.DataItem) HomeModel {
+	m.detail = NewItemDetailModel(m.id, d, m.keyMap)
+	m.detail.SetSize(m.bodySize())
+	m.detailOpen = true
+	return m
+}
+
+// handleDetailAction runs what the buttons and keys of the detail ask for.
+// Going back shows the list as it was left
+func (m HomeModel) handleDetailAction(msg detailActionMsg) (HomeModel, tea.Cmd) {
+	if msg.origin != m.id || !m.detailOpen {
+		return m, nil
+	}
+
+	d := m.detail.Item()
+	switch msg.action {
+	case detailEdit:
+		return m.editItem(d)
+	case detailDelete:
+		return m, m.confirmDelete(d)
+	case detailDuplicate:
+		return m.duplicateItem(d)
+	case detailCopy:
+		return m.copyItem(d)
+	default:
+		m.detailOpen = false
+		return m, nil
+	}
+}
+
+// syncDetail shows the latest version of the item in the detail, and goes
+// back to the list once the item is gone
+func (m *HomeModel) syncDetail() {
+	if !m.detailOpen {
+		return
+	}
+	if i := m.indexOf(m.detail.Item().ID); i >= 0 {
+		m.detail.SetItem(m.items[i].data())
+	} else {
+		m.detailOpen = false
+	}
+}
@@ -0,0 +1,98 @@
+// app/pages/home_import.go
+package pages
+
+import (
+	"bubbletea-app/app/actions"
+	"bubbletea-app/app/config"
+	"bubbletea-app/app/global"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxImportErrors is how many failed rows the import summary explains
+const maxImportErrors = 3
+
+// promptImport asks for the file to import. Nothing changes before the
+// rows in it were looked at in the preview
+func (m HomeModel) promptImport() (HomeModel, tea.Cmd) {
+	return m, func() tea.Msg {
+		return global.SpawnModalMsg{
+			Title:       "Import items",
+			Description: "Read items from a .json or .csv file. You get to check them before anything changes",
+			Prompt:      true,
+			Value:       filepath.Join(config.GetConfigPath(), "exports") + string(filepath.Separator),
+			OnSubmit: func(path string) tea.Msg {
+				return actions.ImportPreviewCmd(m.store, m.id, strings.TrimSpace(path))()
+			},
+		}
+	}
+}
+
+// openPreview shows the rows read from the import file in place of the list
+func (m HomeModel) openPreview(msg actions.ImportPreviewMsg) (HomeModel, tea.Cmd) {
+	if msg.Origin != m.id {
+		return m, nil
+	}
+	if msg.Err != nil {
+		return m, m.notice.ShowError(fmt.Sprintf("Could not import: %v", msg.Err))
+	}
+	m.preview = NewImportPreviewModel(m.id, msg.Path, msg.Rows, m.keyMap)
+	m.preview.SetSize(m.bodySize())
+	m.previewOpen = true
+	return m, nil
+}
+
+// handleImportAction imports the previewed rows, or goes back to the list
+func (m HomeModel) handleImportAction(msg importActionMsg) (HomeModel, tea.Cmd) {
+	if msg.origin != m.id || !m.previewOpen {
+		return m, nil
+	}
+	m.previewOpen = false
+	if msg.action == importCancel {
+		return m, nil
+	}
+	return m, tea.Batch(
+		m.notice.Show(fmt.Sprintf("Importing %d items…", len(m.preview.Rows()))),
+		actions.ImportCmd(m.store, m.id, m.preview.Path(), m.preview.Rows(), m.preview.Mode()),
+	)
+}
+
+// importDone shows the imported items on every page, and sums up the import
+// on the page that made it
+func (m HomeModel) importDone(msg actions.ImportDoneMsg) (HomeModel, tea.Cmd) {
+	var cmd tea.Cmd
+	if msg.Err == nil && m.state == stateLoaded {
+		cmd = m.applyLocal(msg.Applied)
+	}
+	if msg.Origin != m.id {
+		return m, cmd
+	}
+	if msg.Err != nil {
+		return m, m.notice.ShowError(fmt.Sprintf("Could not import: %v", msg.Err))
+	}
+
+	result := msg.Result
+	lines := []string{fmt.Sprintf("Created: %d • Updated: %d • Skipped: %d • Failed: %d",
+		result.Created, result.Updated, result.Skipped, result.Failed)}
+	for i, err := range result.Errors {
+		if i == maxImportErrors {
+			lines = append(lines, fmt.Sprintf("…and %d more", len(result.Errors)-i))
+			break
+		}
+		lines = append(lines, err.Error())
+	}
+	if result.Created+result.Updated > 0 {
+		lines = append(lines, fmt.Sprintf("Press %s to undo the import.", m.keyMap.Undo.Help().Key))
+	}
+
+	summary := func() tea.Msg {
+		return global.SpawnModalMsg{
+			Title:       "Imported " + filepath.Base(msg.Path),
+			Description: strings.Join(lines, "\n"),
+		}
+	}
+	return m, tea.Batch(cmd, m.notice.Show("Import finished"), summary)
+}
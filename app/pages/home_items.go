@@ -0,0 +1,275 @@
+// app/pages/home_items.go
+package pages
+
+import (
+	"bubbletea-app/app/actions"
+	"bubbletea-app/app/components"
+	"bubbletea-app/app/global"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// formWidth is the widest the add and edit forms get
+const formWidth = 60
+
+// mutateMsg asks the Home page with id origin to apply a mutation. Forms and
+// modals send it once the user confirmed the change
+type mutateMsg struct {
+	origin   string
+	mutation actions.Mutation
+}
+
+func toItem(d actions.DataItem) item {
+	return item{id: d.ID, title: d.Title, desc: d.Description, status: d.Status, created: d.Created}
+}
+
+func (i item) data() actions.DataItem {
+	return actions.DataItem{ID: i.id, Title: i.title, Description: i.desc, Status: i.status, Created: i.created}
+}
+
+// handleItemKey handles the keys that open, add, import, edit, delete,
+// duplicate, copy and undo items. It reports whether msg was one of them
+func (m HomeModel) handleItemKey(msg tea.KeyMsg) (HomeModel, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keyMap.Undo):
+		mutation, ok := m.store.History.StartUndo()
+		if !ok {
+			return m, m.notice.Show("Nothing to undo"), true
+		}
+		model, cmd := m.replay(mutation, actions.OpUndo)
+		return model, cmd, true
+
+	case key.Matches(msg, m.keyMap.Redo):
+		mutation, ok := m.store.History.StartRedo()
+		if !ok {
+			return m, m.notice.Show("Nothing to redo"), true
+		}
+		model, cmd := m.replay(mutation, actions.OpRedo)
+		return model, cmd, true
+	}
+
+	if key.Matches(msg, m.keyMap.Add) {
+		model, cmd := m.openForm("Add item", actions.DataItem{}, func(values []string) tea.Msg {
+			item := actions.DataItem{Title: values[0], Description: values[1]}
+			return mutateMsg{origin: m.id, mutation: actions.CreateItem(item, len(m.items))}
+		})
+		return model, cmd, true
+	}
+	if key.Matches(msg, m.keyMap.Import) {
+		model, cmd := m.promptImport()
+		return model, cmd, true
+	}
+
+	selected, ok := m.list.SelectedItem().(item)
+	if !ok {
+		return m, nil, false
+	}
+
+	switch {
+	case key.Matches(msg, m.keyMap.Enter):
+		return m.openDetail(selected.data()), nil, true
+
+	case key.Matches(msg, m.keyMap.Edit):
+		model, cmd := m.editItem(selected.data())
+		return model, cmd, true
+
+	case key.Matches(msg, m.keyMap.Delete):
+		return m, m.confirmDelete(selected.data()), true
+
+	case key.Matches(msg, m.keyMap.Duplicate):
+		model, cmd := m.duplicateItem(selected.data())
+		return model, cmd, true
+
+	case key.Matches(msg, m.keyMap.Copy):
+		model, cmd := m.copyItem(selected.data())
+		return model, cmd, true
+	}
+	return m, nil, false
+}
+
+// editItem opens the form for changing the title and description of before
+func (m HomeModel) editItem(before actions.DataItem) (HomeModel, tea.Cmd) {
+	// Mutations refer to positions among all items, not just the matching ones
+	index := m.indexOf(before.ID)
+	return m.openForm("Edit item", before, func(values []string) tea.Msg {
+		after := before
+		after.Title, after.Description = values[0], values[1]
+		return mutateMsg{origin: m.id, mutation: actions.UpdateItem(before, after, index)}
+	})
+}
+
+// confirmDelete asks before deleting d
+func (m HomeModel) confirmDelete(d actions.DataItem) tea.Cmd {
+	mutation := actions.DeleteItem(d, m.indexOf(d.ID))
+	return func() tea.Msg {
+		return global.SpawnModalMsg{
+			Title:       "Delete item?",
+			Description: fmt.Sprintf("Delete '%s'? Press %s afterwards to undo.", d.Title, m.keyMap.Undo.Help().Key),
+			OnConfirm: func() tea.Msg {
+				return mutateMsg{origin: m.id, mutation: mutation}
+			},
+		}
+	}
+}
+
+// duplicateItem adds a copy of d right after it
+func (m HomeModel) duplicateItem(d actions.DataItem) (HomeModel, tea.Cmd) {
+	copied := actions.DataItem{Title: d.Title + " (copy)", Description: d.Description}
+	return m.mutate(actions.CreateItem(copied, m.indexOf(d.ID)+1))
+}
+
+// openForm shows a form for the title and description of item. Keys go to
+// the form until it is closed
+func (m HomeModel) openForm(title string, item actions.DataItem, onSubmit func(values []string) tea.Msg) (HomeModel, tea.Cmd) {
+	m.form = components.NewFormModel(title, m.keyMap,
+		components.FormField{Label: "Title", Placeholder: "What needs doing?", Value: item.Title},
+		components.FormField{Label: "Description", Placeholder: "Optional details", Value: item.Description},
+	)
+	m.form.Validate = func(values []string) error {
+		if values[0] == "" {
+			return errors.New("the title can't be empty")
+		}
+		return nil
+	}
+	m.form.OnSubmit = onSubmit
+	width, _ := m.bodySize()
+	m.form.SetWidth(min(width, formWidth))
+
+	return m, tea.Batch(m.form.Init(), func() tea.Msg {
+		return global.InputFocusChangedMsg(true)
+	})
+}
+
+// updateForm passes msg to the open form and gives the keys back to the app
+// once it closes
+func (m HomeModel) updateForm(msg tea.Msg) (HomeModel, tea.Cmd) {
+	var cmd tea.Cmd
+	m.form, cmd = m.form.Update(msg)
+	if m.form.IsOpen() {
+		return m, cmd
+	}
+	return m, tea.Batch(cmd, func() tea.Msg {
+		return global.InputFocusChangedMsg(false)
+	})
+}
+
+// mutate applies mutation to the list right away and sends it to the
+// backend, which may still reject it
+func (m HomeModel) mutate(mutation actions.Mutation) (HomeModel, tea.Cmd) {
+	return m.replay(mutation, actions.OpDo)
+}
+
+// replay applies a new change or one from the undo history
+func (m HomeModel) replay(mutation actions.Mutation, op actions.HistoryOp) (HomeModel, tea.Cmd) {
+	cmd := m.applyLocal(mutation)
+	// The list keeps its selection while it is hidden behind the detail
+	if mutation.Kind == actions.MutationCreate && !m.detailOpen {
+		if i := m.visibleIndexOf(mutation.After.ID); i >= 0 {
+			m.list.Select(i)
+		}
+	}
+	return m, tea.Batch(cmd, actions.MutateCmd(m.store, m.id, mutation, op))
+}
+
+func (m HomeModel) mutationDone(msg actions.MutationDoneMsg) (HomeModel, tea.Cmd) {
+	// Other pages showing the same items catch up now
+	var cmd tea.Cmd
+	if msg.Origin != m.id {
+		cmd = m.applyLocal(msg.Mutation)
+	} else {
+		cmd = m.notice.Show(m.describe(msg.Op, msg.Mutation))
+	}
+
+	// The backend may have changed the items, for example given them its own
+	// IDs. The event for such an item can beat the answer here and add it a
+	// second time, under the new ID
+	stored := msg.Stored.Leaves()
+	m.items = slices.Clone(m.items)
+	for i, leaf := range msg.Mutation.Leaves() {
+		if leaf.Kind == actions.MutationDelete || i >= len(stored) {
+			continue
+		}
+		if id := stored[i].After.ID; id != leaf.After.ID {
+			if j := m.indexOf(id); j >= 0 && m.indexOf(leaf.After.ID) >= 0 {
+				m.applyItems(actions.DeleteItem(m.items[j].data(), j))
+			}
+		}
+		m.setItem(leaf.After.ID, stored[i].After)
+	}
+	return m, tea.Batch(cmd, m.applyFilter())
+}
+
+func (m HomeModel) mutationFailed(msg actions.MutationFailedMsg) (HomeModel, tea.Cmd) {
+	if msg.Origin != m.id {
+		return m, nil
+	}
+	notify := m.notice.ShowError(fmt.Sprintf("Could not save %s: %v", msg.Mutation.Subject(), msg.Err))
+	return m, tea.Batch(notify, m.applyLocal(msg.Mutation.Inverse()))
+}
+
+// describe tells what a finished change did and how to take it back
+func (m HomeModel) describe(op actions.HistoryOp, mutation actions.Mutation) string {
+	switch op {
+	case actions.OpUndo:
+		return fmt.Sprintf("Undone: %s — press %s to redo", mutation.Inverse().Describe(), m.keyMap.Redo.Help().Key)
+	case actions.OpRedo:
+		return fmt.Sprintf("Redone: %s — press %s to undo", mutation.Describe(), m.keyMap.Undo.Help().Key)
+	}
+	return fmt.Sprintf("%s — press %s to undo", mutation.Describe(), m.keyMap.Undo.Help().Key)
+}
+
+// applyLocal changes the items the way mutation changes the backend
+func (m *HomeModel) applyLocal(mutation actions.Mutation) tea.Cmd {
+	m.items = slices.Clone(m.items)
+	m.applyItems(mutation)
+	return m.applyFilter()
+}
+
+func (m *HomeModel) applyItems(mutation actions.Mutation) {
+	switch mutation.Kind {
+	case actions.MutationCreate:
+		index := min(max(mutation.Index, 0), len(m.items))
+		m.items = slices.Insert(m.items, index, toItem(mutation.After))
+		// Backends add new items at the end, which is only loaded once
+		// there are no more pages. Otherwise the item comes again with the
+		// last page, which skips it
+		if !m.hasMore() {
+			m.loaded++
+		}
+		m.total++
+
+	case actions.MutationUpdate:
+		m.setItem(mutation.Before.ID, mutation.After)
+
+	case actions.MutationDelete:
+		if i := m.indexOf(mutation.Before.ID); i >= 0 {
+			m.items = slices.Delete(m.items, i, i+1)
+			// The next page starts one item earlier now
+			m.loaded = max(m.loaded-1, 0)
+			m.total--
+		}
+
+	case actions.MutationBatch:
+		for _, child := range mutation.Children {
+			m.applyItems(child)
+		}
+	}
+}
+
+// setItem replaces the item with id by d, keeping it selected if it was
+func (m *HomeModel) setItem(id string, d actions.DataItem) {
+	if i := m.indexOf(id); i >= 0 {
+		selected := m.items[i].selected
+		m.items[i] = toItem(d)
+		m.items[i].selected = selected
+	}
+}
+
+// indexOf returns the position of the item with id among all items, or -1
+func (m HomeModel) indexOf(id string) int {
+	return slices.IndexFunc(m.items, func(it item) bool { return it.id == id })
+}
@@ -0,0 +1,140 @@
+// app/pages/home_paging.go
+package pages
+
+import (
+	"bubbletea-app/app/actions"
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// loadMoreThreshold is how close to the end of the list the cursor gets
+// before the next page is fetched
+const loadMoreThreshold = 5
+
+// hasMore reports whether the backend holds items that aren't loaded yet.
+// loaded counts the items fetched so far, which is where the next page
+// starts, and total counts all items in the backend
+func (m HomeModel) hasMore() bool {
+	return m.loaded < m.total
+}
+
+// listSize returns the space for the list, which gives up its last line to
+// the loading row while there are more pages
+func (m HomeModel) listSize() (int, int) {
+	width, height := m.bodySize()
+	if m.hasMore() {
+		height--
+	}
+	return width, max(height, 1)
+}
+
+// loadMore fetches the next page once the cursor gets near the end of the
+// list
+func (m HomeModel) loadMore() (HomeModel, tea.Cmd) {
+	if m.state != stateLoaded || m.loadingMore || !m.hasMore() {
+		return m, nil
+	}
+	if m.list.Index() < len(m.list.Items())-loadMoreThreshold {
+		return m, nil
+	}
+	m.loadingMore = true
+	m.moreOffset = m.loaded
+	return m, tea.Batch(m.spinner.Tick, actions.LoadPageCmd(m.fetchCtx, m.store, m.loaded))
+}
+
+// pageLoaded shows a fetched page. The first page replaces the items, later
+// ones are added at the end. Pages fetched by other pages sharing the store
+// are taken as well
+func (m HomeModel) pageLoaded(msg actions.DataLoadedMsg) (HomeModel, tea.Cmd) {
+	if msg.Offset == 0 {
+		m.state = stateLoaded
+		m.err = nil
+		m.interrupted = false
+		m.offline = msg.Offline
+		m.lastRefresh = time.Now()
+		if !msg.Cached.IsZero() {
+			m.lastRefresh = msg.Cached
+		}
+		items := listItems(msg.Items)
+		highlight := m.markChanged(items)
+		m.items = items
+		m.refreshing = false
+		m.loaded = len(msg.Items)
+		m.total = msg.Total
+		m.loadingMore = false
+		return m, tea.Batch(m.applyFilter(), highlight)
+	}
+
+	own := m.loadingMore && msg.Offset == m.moreOffset
+	if own {
+		m.loadingMore = false
+	}
+	if m.state != stateLoaded {
+		return m, nil
+	}
+	// Items deleted meanwhile moved the rest down, so the page skipped some
+	if msg.Offset > m.loaded {
+		if own {
+			return m.loadMore()
+		}
+		return m, nil
+	}
+
+	if msg.Offline {
+		m.offline = true
+	}
+	// Items created or put back meanwhile may be on the page already, and a
+	// cached page may be followed by the same page fresh from the backend
+	m.items = slices.Clone(m.items)
+	for _, d := range msg.Items {
+		if m.indexOf(d.ID) < 0 {
+			m.items = append(m.items, toItem(d))
+		} else {
+			m.setItem(d.ID, d)
+		}
+	}
+	m.loaded = max(m.loaded, msg.Offset+len(msg.Items))
+	m.total = msg.Total
+	return m, m.applyFilter()
+}
+
+// pageFailed tells why the next page could not be fetched. Moving the cursor
+// tries again
+func (m HomeModel) pageFailed(msg actions.DataErrorMsg) (HomeModel, tea.Cmd) {
+	if !m.loadingMore || msg.Offset != m.moreOffset {
+		return m, nil
+	}
+	m.loadingMore = false
+	return m, m.notice.ShowError(fmt.Sprintf("Could not load more items: %v", msg.Err))
+}
+
+// countView tells how many of the items are loaded
+func (m HomeModel) countView() string {
+	if m.hasMore() {
+		return fmt.Sprintf("%d of %d items", len(m.items), m.total)
+	}
+	return fmt.Sprintf("%d items", len(m.items))
+}
+
+// moreView is the row under the list while there are more pages
+func (m HomeModel) moreView() string {
+	style := lipgloss.NewStyle().PaddingLeft(2).Foreground(lipgloss.Color("#888888"))
+	if m.loadingMore {
+		return style.Render(m.spinner.View() + "Loading more…")
+	}
+	return style.Render(fmt.Sprintf("%d more below", m.total-m.loaded))
+}
+
+// cachedTime formats when cached items were fetched, with the day unless it
+// was today
+func cachedTime(t time.Time) string {
+	t, now := t.Local(), time.Now()
+	if t.YearDay() == now.YearDay() && t.Year() == now.Year() {
+		return t.Format("15:04")
+	}
+	return t.Format("2 Jan 15:04")
+}
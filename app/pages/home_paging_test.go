@@ -0,0 +1,112 @@
+// app/pages/home_paging_test.go
+package pages
+
+import (
+	"bubbletea-app/app/actions"
+	"bubbletea-app/app/config"
+	"strconv"
+	"testing"
+)
+
+// pageOf returns count items numbered from offset on
+func pageOf(offset, count int) []actions.DataItem {
+	items := make([]actions.DataItem, count)
+	for i := range items {
+		id := strconv.Itoa(offset + i + 1)
+		items[i] = actions.DataItem{ID: id, Title: "Task " + id}
+	}
+	return items
+}
+
+// newPagedHome returns Home with the first page of total items loaded
+func newPagedHome(t *testing.T, total int) HomeModel {
+	t.Helper()
+	store := actions.NewStore(config.Settings{Backend: config.BackendMock})
+	t.Cleanup(func() { store.Close() })
+	m := NewHomeModel(config.DefaultKeyMap(), store)
+	m, _ = m.pageLoaded(actions.DataLoadedMsg{Items: pageOf(0, min(total, actions.PageSize)), Total: total})
+	return m
+}
+
+func TestHomeLoadMore(t *testing.T) {
+	tests := []struct {
+		name   string
+		total  int
+		cursor int
+		want   bool
+	}{
+		{"far from the end", 120, 10, false},
+		{"near the end", 120, actions.PageSize - loadMoreThreshold, true},
+		{"at the end", 120, actions.PageSize - 1, true},
+		{"all loaded", 30, 29, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newPagedHome(t, tt.total)
+			m.list.Select(tt.cursor)
+			m, cmd := m.loadMore()
+			if fetched := cmd != nil; fetched != tt.want || m.loadingMore != tt.want {
+				t.Fatalf("fetched %t, loading more %t, want %t", fetched, m.loadingMore, tt.want)
+			}
+			if tt.want && m.moreOffset != actions.PageSize {
+				t.Errorf("fetching from %d, want %d", m.moreOffset, actions.PageSize)
+			}
+		})
+	}
+}
+
+func TestHomeLoadMoreOnce(t *testing.T) {
+	m := newPagedHome(t, 120)
+	m.list.Select(actions.PageSize - 1)
+	m, cmd := m.loadMore()
+	if cmd == nil {
+		t.Fatal("no fetch at the end of the list")
+	}
+	// The cursor keeps moving while the page is on its way
+	if _, cmd := m.loadMore(); cmd != nil {
+		t.Fatal("fetched the page again while it was loading")
+	}
+
+	// A page fetched by another Home pane isn't the one waited for
+	m, _ = m.pageLoaded(actions.DataLoadedMsg{Items: pageOf(60, 10), Offset: 60, Total: 120})
+	if !m.loadingMore {
+		t.Error("another page ended the wait")
+	}
+
+	m, _ = m.pageLoaded(actions.DataLoadedMsg{Items: pageOf(50, actions.PageSize), Offset: actions.PageSize, Total: 120})
+	if m.loadingMore || m.loaded != 2*actions.PageSize || len(m.items) != 2*actions.PageSize {
+		t.Fatalf("loading more %t with %d loaded and %d items", m.loadingMore, m.loaded, len(m.items))
+	}
+	m.list.Select(len(m.list.Items()) - 1)
+	if m, cmd := m.loadMore(); cmd == nil || m.moreOffset != 2*actions.PageSize {
+		t.Errorf("didn't fetch the last page")
+	}
+}
+
+func TestHomeStreamedCreates(t *testing.T) {
+	created := actions.StreamEventMsg{Event: actions.StreamEvent{
+		Type: actions.EventCreated,
+		Item: actions.DataItem{ID: "new", Title: "Order flowers"},
+	}}
+	tests := []struct {
+		name  string
+		total int
+		// The items shown and the counts after the event
+		wantItems, wantLoaded, wantTotal int
+		wantMore                         bool
+	}{
+		// The new item comes with the last page, where the backend adds it
+		{"more pages", 120, actions.PageSize, actions.PageSize, 121, true},
+		{"all loaded", 30, 31, 31, 31, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newPagedHome(t, tt.total)
+			m, _ = m.streamEvent(created)
+			if len(m.items) != tt.wantItems || m.loaded != tt.wantLoaded || m.total != tt.wantTotal || m.hasMore() != tt.wantMore {
+				t.Errorf("got %d items, %d of %d loaded, more %t, want %d items, %d of %d, more %t",
+					len(m.items), m.loaded, m.total, m.hasMore(), tt.wantItems, tt.wantLoaded, tt.wantTotal, tt.wantMore)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// app/pages/home_panel.go
+package pages
+
+import (
+	"bubbletea-app/app/actions"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ItemSelectedMsg tells which item is under the cursor of the Home list,
+// for panels that show it. Selected is false when the list is empty
+type ItemSelectedMsg struct {
+	Item     actions.DataItem
+	Selected bool
+}
+
+// announceSelection sends ItemSelectedMsg when the item under the cursor, or
+// the version of it, changed since the last one
+func (m *HomeModel) announceSelection() tea.Cmd {
+	var msg ItemSelectedMsg
+	if selected, ok := m.list.SelectedItem().(item); ok {
+		msg = ItemSelectedMsg{Item: selected.data(), Selected: true}
+	}
+	if msg.Selected == m.announced.Selected && msg.Item.Equal(m.announced.Item) {
+		return nil
+	}
+	m.announced = msg
+	return func() tea.Msg { return msg }
+}
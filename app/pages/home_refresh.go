@@ -0,0 +1,122 @@
+// app/pages/home_refresh.go
+package pages
+
+import (
+	"bubbletea-app/app/actions"
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// highlightDuration is how long rows changed by a refresh stay highlighted
+const highlightDuration = 3 * time.Second
+
+// highlightExpiredMsg ends the highlight of the rows changed by refresh seq
+// of the Home page with id origin, unless a newer refresh changed rows
+type highlightExpiredMsg struct {
+	origin string
+	seq    int
+}
+
+// autoRefresh fetches the items again in the background when the scheduler
+// says so and the page isn't busy
+func (m HomeModel) autoRefresh(msg tea.Msg) (HomeModel, tea.Cmd) {
+	var due bool
+	var cmd tea.Cmd
+	m.scheduler, due, cmd = m.scheduler.Update(msg)
+	if !due || !m.idle() {
+		return m, cmd
+	}
+	m, refresh := m.backgroundRefresh()
+	return m, tea.Batch(cmd, refresh)
+}
+
+// idle reports whether a background refresh can run: the page is shown and
+// loaded, nothing is being fetched and no form, search, detail or import
+// preview is open
+func (m HomeModel) idle() bool {
+	return m.fetchCtx.Err() == nil && m.state == stateLoaded && !m.loadingMore && !m.refreshing &&
+		!m.form.IsOpen() && !m.searching && !m.detailOpen && !m.previewOpen
+}
+
+// backgroundRefresh fetches as many items as are loaded, without the
+// spinner. The list stays as it is until the answer is in
+func (m HomeModel) backgroundRefresh() (HomeModel, tea.Cmd) {
+	m.refreshing = true
+	return m, actions.ReloadItemsCmd(m.fetchCtx, m.store, m.loaded)
+}
+
+// refreshOverdue refreshes right away when the page was hidden for longer
+// than the refresh interval
+func (m HomeModel) refreshOverdue() (HomeModel, tea.Cmd) {
+	interval := m.scheduler.Interval()
+	if interval <= 0 || time.Since(m.lastRefresh) < interval || !m.idle() {
+		return m, nil
+	}
+	return m.backgroundRefresh()
+}
+
+// markChanged carries the selection and highlights over to the reloaded
+// items and, after a refresh, highlights the items that are new or changed
+// for a while
+func (m *HomeModel) markChanged(items []item) tea.Cmd {
+	previous := make(map[string]item, len(m.items))
+	for _, it := range m.items {
+		previous[it.id] = it
+	}
+
+	changed := false
+	for i := range items {
+		old, ok := previous[items[i].id]
+		items[i].selected = old.selected
+		items[i].changed = old.changed
+		if m.refreshing && len(previous) > 0 && (!ok || !old.data().Equal(items[i].data())) {
+			items[i].changed = true
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return m.highlight()
+}
+
+// highlight keeps the rows marked as changed highlighted for a while, longer
+// when more change meanwhile
+func (m *HomeModel) highlight() tea.Cmd {
+	m.changeSeq++
+	msg := highlightExpiredMsg{origin: m.id, seq: m.changeSeq}
+	return tea.Tick(highlightDuration, func(time.Time) tea.Msg {
+		return msg
+	})
+}
+
+// clearHighlight ends the highlight of the changed rows
+func (m HomeModel) clearHighlight(msg highlightExpiredMsg) (HomeModel, tea.Cmd) {
+	if msg.origin != m.id || msg.seq != m.changeSeq {
+		return m, nil
+	}
+	m.items = slices.Clone(m.items)
+	for i := range m.items {
+		m.items[i].changed = false
+	}
+	return m, m.applyFilter()
+}
+
+// loadFailed shows why the items could not be loaded. A list that is shown
+// already stays, a failed refresh in the background only leaves a note
+func (m HomeModel) loadFailed(msg actions.DataErrorMsg) (HomeModel, tea.Cmd) {
+	refreshing := m.refreshing
+	m.refreshing = false
+	if m.state == stateLoading {
+		m.state = stateFailed
+		m.err = msg.Err
+		return m, nil
+	}
+	if refreshing {
+		return m, m.notice.ShowError(fmt.Sprintf("Could not refresh: %v", msg.Err))
+	}
+	return m, nil
+}
@@ -0,0 +1,221 @@
+// app/pages/home_search.go
+package pages
+
+import (
+	"bubbletea-app/app/config"
+	"bubbletea-app/app/global"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// filtersSavedMsg reports the result of persisting the saved filters
+type filtersSavedMsg struct {
+	err error
+}
+
+// searchSource lets fuzzy match over the title and description of items
+type searchSource []item
+
+func (s searchSource) String(i int) string { return s[i].title + " " + s[i].desc }
+func (s searchSource) Len() int            { return len(s) }
+
+// newSearchInput creates the input shown in the status line while searching
+func newSearchInput(keyMap config.KeyMap) textinput.Model {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = "search title and description"
+	input.KeyMap.Paste = keyMap.Paste
+	return input
+}
+
+// startSearch focuses the search input. Keys go to it until the search is
+// closed with Enter or Esc
+func (m HomeModel) startSearch() (HomeModel, tea.Cmd) {
+	if m.state != stateLoaded || m.form.IsOpen() || m.detailOpen || m.previewOpen || m.searching {
+		return m, nil
+	}
+	// The other Home pane may have saved filters meanwhile
+	if saved, err := config.LoadSavedFilters(); err == nil {
+		m.saved = saved
+	}
+	m.savedIndex = -1
+	m.searching = true
+	m.search.SetValue(m.query)
+	m.search.CursorEnd()
+	return m, tea.Batch(m.search.Focus(), func() tea.Msg {
+		return global.InputFocusChangedMsg(true)
+	})
+}
+
+// stopSearch gives the keys back to the app. The filter stays applied
+func (m HomeModel) stopSearch() (HomeModel, tea.Cmd) {
+	m.searching = false
+	m.search.Blur()
+	return m, func() tea.Msg {
+		return global.InputFocusChangedMsg(false)
+	}
+}
+
+// updateSearch handles keys while the search input has focus
+func (m HomeModel) updateSearch(msg tea.KeyMsg) (HomeModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keyMap.Esc):
+		m.query = ""
+		cmd := m.applyFilter()
+		model, stopCmd := m.stopSearch()
+		return model, tea.Batch(cmd, stopCmd)
+
+	case key.Matches(msg, m.keyMap.Enter):
+		return m.stopSearch()
+
+	case key.Matches(msg, m.keyMap.SaveQuery):
+		return m.toggleSaved()
+
+	// Arrow keys only, j and k are typed into the query
+	case msg.Type == tea.KeyUp:
+		return m.cycleSaved(1)
+	case msg.Type == tea.KeyDown:
+		return m.cycleSaved(-1)
+	}
+
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	if query := strings.TrimSpace(m.search.Value()); query != m.query {
+		m.query = query
+		m.savedIndex = -1
+		cmd = tea.Batch(cmd, m.applyFilter())
+	}
+	return m, cmd
+}
+
+// cycleSaved puts the next older (step 1) or newer (step -1) saved filter
+// into the search input
+func (m HomeModel) cycleSaved(step int) (HomeModel, tea.Cmd) {
+	if len(m.saved) == 0 {
+		return m, nil
+	}
+	if m.savedIndex < 0 && step < 0 {
+		m.savedIndex = 0
+	}
+	m.savedIndex = (m.savedIndex + step + len(m.saved)) % len(m.saved)
+	m.query = m.saved[m.savedIndex]
+	m.search.SetValue(m.query)
+	m.search.CursorEnd()
+	return m, m.applyFilter()
+}
+
+// toggleSaved saves the current query, or forgets it when it was saved
+// already. The newest filter comes first
+func (m HomeModel) toggleSaved() (HomeModel, tea.Cmd) {
+	if m.query == "" {
+		return m, nil
+	}
+
+	var text string
+	if i := slices.Index(m.saved, m.query); i >= 0 {
+		m.saved = slices.Delete(slices.Clone(m.saved), i, i+1)
+		text = fmt.Sprintf("Forgot filter '%s'", m.query)
+	} else {
+		m.saved = append([]string{m.query}, m.saved...)
+		text = fmt.Sprintf("Saved filter '%s' — press ↑/↓ while searching to use it", m.query)
+	}
+	m.savedIndex = -1
+
+	saved := m.saved
+	return m, tea.Batch(m.notice.Show(text), func() tea.Msg {
+		return filtersSavedMsg{err: config.SaveFilters(saved)}
+	})
+}
+
+// applyFilter shows the items matching the query in the current sort order,
+// or best match first, and keeps the cursor on the same item if it still
+// matches
+func (m *HomeModel) applyFilter() tea.Cmd {
+	selected, _ := m.list.SelectedItem().(item)
+	index := m.list.Index()
+
+	var matching []item
+	if m.query == "" {
+		matching = slices.Clone(m.items)
+	} else {
+		for _, match := range fuzzy.FindFrom(m.query, searchSource(m.items)) {
+			it := m.items[match.Index]
+			it.titleMatches, it.descMatches = splitMatches(it, match.MatchedIndexes)
+			matching = append(matching, it)
+		}
+	}
+	// Without a sort mode, the best matches stay on top
+	if m.sort != sortNone {
+		slices.SortStableFunc(matching, m.sort.compare)
+	}
+
+	visible := make([]list.Item, len(matching))
+	for i, it := range matching {
+		visible[i] = it
+	}
+	// The loading row under the list goes away with the last page
+	m.list.SetSize(m.listSize())
+	cmd := m.list.SetItems(visible)
+	if i := m.visibleIndexOf(selected.id); i >= 0 {
+		m.list.Select(i)
+	} else {
+		m.list.Select(min(index, max(len(visible)-1, 0)))
+	}
+	m.syncDetail()
+	return cmd
+}
+
+// visibleIndexOf returns the position of the item with id among the items
+// that match the filter, or -1
+func (m HomeModel) visibleIndexOf(id string) int {
+	for i, listItem := range m.list.Items() {
+		if it, ok := listItem.(item); ok && it.id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitMatches turns the byte offsets fuzzy matched in searchSource into rune
+// indexes into the title and the description
+func splitMatches(it item, matched []int) (title, desc []int) {
+	text := searchSource{it}.String(0)
+	titleRunes := utf8.RuneCountInString(it.title)
+
+	r := 0
+	for offset := range text {
+		if slices.Contains(matched, offset) {
+			switch {
+			case r < titleRunes:
+				title = append(title, r)
+			case r > titleRunes:
+				desc = append(desc, r-titleRunes-1)
+			}
+		}
+		r++
+	}
+	return title, desc
+}
+
+func highlight(text string, matches []int, style, match lipgloss.Style) string {
+	unmatched := style.Inline(true)
+	return lipgloss.StyleRunes(text, matches, unmatched.Inherit(match), unmatched)
+}
+
+// searchStatus tells what the list is filtered by, in place of the source
+func (m HomeModel) searchStatus() string {
+	count := fmt.Sprintf("%d of %d", len(m.list.Items()), len(m.items))
+	if m.searching {
+		return m.search.View() + "  " + count
+	}
+	return fmt.Sprintf("Filter: %s • %s • esc to clear", m.query, count)
+}
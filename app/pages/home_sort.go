@@ -0,0 +1,118 @@
+// app/pages/home_sort.go
+package pages
+
+import (
+	"bubbletea-app/app/actions"
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sortMode is the order Home lists its items in
+type sortMode int
+
+const (
+	// sortNone keeps the order of the backend
+	sortNone sortMode = iota
+	sortTitle
+	// sortCreated puts the newest items first
+	sortCreated
+	sortStatus
+)
+
+var sortModeNames = map[sortMode]string{
+	sortNone:    "backend order",
+	sortTitle:   "title",
+	sortCreated: "date added",
+	sortStatus:  "status",
+}
+
+var statusNames = map[string]string{
+	actions.StatusTodo:  "To do",
+	actions.StatusDoing: "Doing",
+	actions.StatusDone:  "Done",
+}
+
+func (s sortMode) String() string {
+	return sortModeNames[s]
+}
+
+// next returns the mode after s, wrapping around to sortNone
+func (s sortMode) next() sortMode {
+	return (s + 1) % sortMode(len(sortModeNames))
+}
+
+// compare orders two items, for slices.SortStableFunc
+func (s sortMode) compare(a, b item) int {
+	switch s {
+	case sortTitle:
+		return strings.Compare(strings.ToLower(a.title), strings.ToLower(b.title))
+	case sortCreated:
+		return b.created.Compare(a.created)
+	case sortStatus:
+		a, b := a.data().ItemStatus(), b.data().ItemStatus()
+		if order := cmp.Compare(statusRank(a), statusRank(b)); order != 0 {
+			return order
+		}
+		// Statuses the app doesn't know stay together
+		return strings.Compare(a, b)
+	}
+	return 0
+}
+
+// statusRank returns the position of status in actions.Statuses. Statuses
+// from elsewhere come after them
+func statusRank(status string) int {
+	if i := slices.Index(actions.Statuses, status); i >= 0 {
+		return i
+	}
+	return len(actions.Statuses)
+}
+
+// group returns the header an item is listed under when sorted by s, or
+// nil when s doesn't group items
+func (s sortMode) group() func(item) string {
+	switch s {
+	case sortTitle:
+		return func(it item) string {
+			first, _ := utf8.DecodeRuneInString(it.title)
+			if !unicode.IsLetter(first) {
+				return "#"
+			}
+			return string(unicode.ToUpper(first))
+		}
+	case sortCreated:
+		return func(it item) string {
+			if it.created.IsZero() {
+				return "Unknown date"
+			}
+			return it.created.Local().Format("Mon 2 Jan 2006")
+		}
+	case sortStatus:
+		return func(it item) string {
+			status := it.data().ItemStatus()
+			if name, ok := statusNames[status]; ok {
+				return name
+			}
+			return status
+		}
+	}
+	return nil
+}
+
+// cycleSort switches to the next sort mode, with group headers to match
+func (m HomeModel) cycleSort() (HomeModel, tea.Cmd) {
+	m.sort = m.sort.next()
+	m.list.SetDelegate(m.delegate())
+	return m, tea.Batch(m.applyFilter(), m.notice.Show("Sorted by "+m.sort.String()))
+}
+
+// delegate returns the item delegate for the current sort mode
+func (m HomeModel) delegate() itemDelegate {
+	return itemDelegate{DefaultDelegate: list.NewDefaultDelegate(), prefix: m.id, group: m.sort.group()}
+}
@@ -0,0 +1,79 @@
+// app/pages/home_sort_test.go
+package pages
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSortModes(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 9, 0, 0, 0, time.UTC) }
+	items := []item{
+		{id: "1", title: "write the report", status: "done", created: day(6)},
+		{id: "2", title: "Book the venue", status: "blocked", created: day(8)},
+		{id: "3", title: "2 speakers", created: day(7)},
+		{id: "4", title: "Ask Sam", status: "doing", created: day(9)},
+		{id: "5", title: "Call the caterer", status: "archived"},
+		{id: "6", title: "book flights", status: "blocked", created: day(8)},
+	}
+	tests := []struct {
+		mode   sortMode
+		ids    []string
+		groups []string
+	}{
+		{sortNone, []string{"1", "2", "3", "4", "5", "6"}, nil},
+		{sortTitle, []string{"3", "4", "6", "2", "5", "1"},
+			[]string{"#", "A", "B", "B", "C", "W"}},
+		{sortCreated, []string{"4", "2", "6", "3", "1", "5"},
+			[]string{"Thu 9 Jan 2025", "Wed 8 Jan 2025", "Wed 8 Jan 2025", "Tue 7 Jan 2025", "Mon 6 Jan 2025", "Unknown date"}},
+		// No status counts as to do, statuses the app doesn't know come
+		// last under their own name
+		{sortStatus, []string{"3", "4", "1", "5", "2", "6"},
+			[]string{"To do", "Doing", "Done", "archived", "blocked", "blocked"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			sorted := slices.Clone(items)
+			slices.SortStableFunc(sorted, tt.mode.compare)
+			var ids, groups []string
+			group := tt.mode.group()
+			for _, it := range sorted {
+				ids = append(ids, it.id)
+				if group != nil {
+					groups = append(groups, group(it))
+				}
+			}
+			if !slices.Equal(ids, tt.ids) {
+				t.Errorf("sorted %v, want %v", ids, tt.ids)
+			}
+			if !slices.Equal(groups, tt.groups) {
+				t.Errorf("grouped %q, want %q", groups, tt.groups)
+			}
+		})
+	}
+}
+
+func TestSplitMatches(t *testing.T) {
+	it := item{title: "Café menu", desc: "Ask Zoë"}
+	tests := []struct {
+		name string
+		// matched are byte offsets into "Café menu Ask Zoë"
+		matched     []int
+		title, desc []int
+	}{
+		{"title", []int{0, 1}, []int{0, 1}, nil},
+		{"after a wide rune", []int{6, 7}, []int{5, 6}, nil},
+		{"description", []int{11, 17}, nil, []int{0, 6}},
+		{"both", []int{3, 15}, []int{3}, []int{4}},
+		{"the space between", []int{10}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, desc := splitMatches(it, tt.matched)
+			if !slices.Equal(title, tt.title) || !slices.Equal(desc, tt.desc) {
+				t.Errorf("got title %v and description %v, want %v and %v", title, desc, tt.title, tt.desc)
+			}
+		})
+	}
+}
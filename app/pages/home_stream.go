@@ -0,0 +1,52 @@
+// app/pages/home_stream.go
+package pages
+
+import (
+	"bubbletea-app/app/actions"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// streamEvent applies a change streamed by the API and highlights the item.
+// Changes made here come back as events too, and find the items changed
+// already
+func (m HomeModel) streamEvent(msg actions.StreamEventMsg) (HomeModel, tea.Cmd) {
+	// A load that is running brings the change along
+	if m.state != stateLoaded {
+		return m, nil
+	}
+
+	event := msg.Event
+	i := m.indexOf(event.Item.ID)
+	m.items = slices.Clone(m.items)
+	switch {
+	case event.Type == actions.EventDeleted:
+		if i < 0 {
+			return m, nil
+		}
+		m.applyItems(actions.DeleteItem(m.items[i].data(), i))
+		return m, m.applyFilter()
+
+	case i >= 0:
+		if m.items[i].data().Equal(event.Item) {
+			return m, nil
+		}
+		m.setItem(event.Item.ID, event.Item)
+		m.items[i].changed = true
+
+	case event.Type == actions.EventCreated && m.hasMore():
+		// New items come last, with the last page
+		m.total++
+		return m, nil
+
+	case event.Type == actions.EventCreated:
+		m.applyItems(actions.CreateItem(event.Item, len(m.items)))
+		m.items[len(m.items)-1].changed = true
+
+	default:
+		// Updated items that aren't loaded yet come with their page
+		return m, nil
+	}
+	return m, tea.Batch(m.applyFilter(), m.highlight())
+}
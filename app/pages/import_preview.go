@@ -0,0 +1,257 @@
+// app/pages/import_preview.go
+package pages
+
+import (
+	"bubbletea-app/app/actions"
+	"bubbletea-app/app/components"
+	"bubbletea-app/app/config"
+	"bubbletea-app/app/layout"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// importAction is what the import preview asks Home to do
+type importAction int
+
+const (
+	importConfirm importAction = iota
+	importCancel
+)
+
+// importActionMsg is sent by the import preview to the Home page with id
+// origin
+type importActionMsg struct {
+	origin string
+	action importAction
+}
+
+// ImportPreviewModel shows the rows read from an import file as a table,
+// with the ones that can't be imported in red and the ones the backend has
+// already in amber, and asks what to do with the latter. Home shows it in
+// place of the list
+type ImportPreviewModel struct {
+	path    string
+	rows    []actions.ImportRow
+	table   viewport.Model
+	mode    *components.SelectModel
+	buttons components.ButtonBarModel
+	nav     *config.NavigationManager
+	keyMap  config.KeyMap
+	width   int
+	height  int
+}
+
+// NewImportPreviewModel creates the preview of the rows read from path for
+// the Home page with id origin
+func NewImportPreviewModel(origin, path string, rows []actions.ImportRow, keyMap config.KeyMap) ImportPreviewModel {
+	modes := make([]string, len(actions.ConflictModes))
+	for i, mode := range actions.ConflictModes {
+		modes[i] = mode.String()
+	}
+
+	m := ImportPreviewModel{
+		path:   path,
+		rows:   rows,
+		table:  viewport.New(0, 0),
+		mode:   components.NewSelectModel(modes...),
+		nav:    config.NewNavigationManager(),
+		keyMap: keyMap,
+	}
+	m.nav.AddItemAt(m.mode, 0, 0)
+	m.buttons = components.NewButtonBarModel(m.nav, 1, keyMap, func(index int) tea.Msg {
+		return importActionMsg{origin: origin, action: importAction(index)}
+	}, "Import", "Cancel").WithBack(int(importCancel))
+	m.buttons.Focus(int(importConfirm))
+	return m
+}
+
+// Path returns the file the rows were read from
+func (m ImportPreviewModel) Path() string {
+	return m.path
+}
+
+// Rows returns the rows read from the file
+func (m ImportPreviewModel) Rows() []actions.ImportRow {
+	return m.rows
+}
+
+// Mode returns what to do with the items the backend has already
+func (m ImportPreviewModel) Mode() actions.ConflictMode {
+	for _, mode := range actions.ConflictModes {
+		if mode.String() == m.mode.Value() {
+			return mode
+		}
+	}
+	return actions.ConflictSkip
+}
+
+// SetSize sets the area the preview renders into
+func (m *ImportPreviewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	table := m.layout().Layout(width, height)["table"]
+	m.table.Width = table.Width
+	m.table.Height = table.Height
+	m.table.SetContent(m.tableView())
+}
+
+// counts returns how many rows can't be imported and how many the backend
+// has already
+func (m ImportPreviewModel) counts() (invalid, existing int) {
+	for _, row := range m.rows {
+		switch {
+		case row.Err != nil:
+			invalid++
+		case row.Existing != nil:
+			existing++
+		}
+	}
+	return invalid, existing
+}
+
+// layout puts the file and a summary above the table, and the choice for
+// existing items and the buttons below it
+func (m ImportPreviewModel) layout() layout.Flex {
+	return layout.Column(
+		layout.Fixed("title", 1),
+		layout.Fixed("summary", 1),
+		layout.Flexible("table", 1),
+		layout.Fixed("mode", 1),
+		layout.Fixed("buttons", 3),
+	)
+}
+
+func (m ImportPreviewModel) Update(msg tea.Msg) (ImportPreviewModel, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case tea.MouseMsg:
+		if cmd, ok := m.buttons.HandleMouse(msg); ok {
+			return m, cmd
+		}
+		if m.mode.HandleMouse(msg) {
+			m.nav.FocusItem(m.mode)
+			m.table.SetContent(m.tableView())
+			return m, nil
+		}
+		// The wheel scrolls the table
+		m.table, cmd = m.table.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m ImportPreviewModel) handleKey(msg tea.KeyMsg) (ImportPreviewModel, tea.Cmd) {
+	if cmd, ok := m.buttons.HandleKey(msg); ok {
+		return m, cmd
+	}
+	// Up and down scroll, the other navigation keys move between the
+	// choice and the buttons
+	if key.Matches(msg, m.keyMap.Up) || key.Matches(msg, m.keyMap.Down) {
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	}
+
+	// Left and right flip through the choices
+	if m.mode.IsFocused() {
+		switch {
+		case key.Matches(msg, m.keyMap.Left):
+			m.mode.Previous()
+			m.table.SetContent(m.tableView())
+			return m, nil
+		case key.Matches(msg, m.keyMap.Right):
+			m.mode.Next()
+			m.table.SetContent(m.tableView())
+			return m, nil
+		}
+	}
+
+	if !m.nav.HandleKey(msg, m.keyMap) {
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// tableView renders a line per row: where it is in the file, the title,
+// status and ID, and what the import does with it
+func (m ImportPreviewModel) tableView() string {
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	invalid := lipgloss.NewStyle().Foreground(lipgloss.Color("#F44336"))
+	existing := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB86C"))
+	created := lipgloss.NewStyle().Foreground(lipgloss.Color("#25A065"))
+
+	// The title and the note share what the fixed columns leave
+	const lineWidth, statusWidth, idWidth = 5, 6, 10
+	rest := max(m.table.Width-lineWidth-statusWidth-idWidth-4, 2)
+	titleWidth := rest / 2
+
+	cell := func(text string, width int) string {
+		text = ansi.Truncate(strings.ReplaceAll(text, "\n", " "), width, "…")
+		return text + strings.Repeat(" ", max(width-ansi.StringWidth(text), 0))
+	}
+
+	lines := []string{muted.Render(strings.Join([]string{
+		cell("Line", lineWidth), cell("Title", titleWidth), cell("Status", statusWidth), cell("ID", idWidth), "",
+	}, " "))}
+	for _, row := range m.rows {
+		style, note := created, "new"
+		switch {
+		case row.Err != nil:
+			style, note = invalid, row.Err.Error()
+		case row.Existing != nil:
+			style, note = existing, map[actions.ConflictMode]string{
+				actions.ConflictSkip:      "exists, skipped",
+				actions.ConflictOverwrite: "exists, overwritten",
+				actions.ConflictMerge:     "exists, merged",
+			}[m.Mode()]
+		}
+		line := strings.Join([]string{
+			cell(fmt.Sprint(row.Line), lineWidth),
+			cell(row.Item.Title, titleWidth),
+			cell(row.Item.Status, statusWidth),
+			cell(row.Item.ID, idWidth),
+			cell(note, rest-titleWidth),
+		}, " ")
+		lines = append(lines, style.Render(line))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m ImportPreviewModel) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFFFFF")).PaddingLeft(1).
+		Render(ansi.Truncate("Import "+m.path, max(m.width-1, 1), "…"))
+
+	invalid, existing := m.counts()
+	summary := fmt.Sprintf("%d items", len(m.rows))
+	if invalid > 0 {
+		summary += fmt.Sprintf(" • %d can't be imported", invalid)
+	}
+	if existing > 0 {
+		summary += fmt.Sprintf(" • %d in the backend already", existing)
+	}
+	summary = lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")).PaddingLeft(1).Render(summary)
+
+	label := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	if m.mode.IsFocused() {
+		label = label.Bold(true).Foreground(lipgloss.Color("#25A065"))
+	}
+	mode := lipgloss.NewStyle().PaddingLeft(1).Render(label.Render("Existing items:") + " " + m.mode.View())
+
+	return m.layout().Render(m.width, m.height, map[string]string{
+		"title":   title,
+		"summary": summary,
+		"table":   m.table.View(),
+		"mode":    mode,
+		"buttons": m.buttons.View(),
+	})
+}
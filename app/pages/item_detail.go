@@ -0,0 +1,182 @@
+// app/pages/item_detail.go
+package pages
+
+import (
+	"bubbletea-app/app/actions"
+	"bubbletea-app/app/components"
+	"bubbletea-app/app/config"
+	"bubbletea-app/app/layout"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// detailAction is what the item detail asks Home to do
+type detailAction int
+
+const (
+	detailEdit detailAction = iota
+	detailDelete
+	detailDuplicate
+	detailBack
+	// detailCopy has a key but no button
+	detailCopy
+)
+
+// detailActionMsg is sent by the item detail to the Home page with id origin
+type detailActionMsg struct {
+	origin string
+	action detailAction
+}
+
+// ItemDetailModel shows one item with its description rendered as Markdown,
+// its metadata and buttons to act on it. Home shows it in place of the list,
+// which keeps its scroll position and selection meanwhile
+type ItemDetailModel struct {
+	origin   string
+	item     actions.DataItem
+	markdown components.MarkdownModel
+	buttons  components.ButtonBarModel
+	nav      *config.NavigationManager
+	keyMap   config.KeyMap
+	width    int
+	height   int
+}
+
+// NewItemDetailModel creates the detail view of item for the Home page with
+// id origin
+func NewItemDetailModel(origin string, item actions.DataItem, keyMap config.KeyMap) ItemDetailModel {
+	m := ItemDetailModel{
+		origin:   origin,
+		item:     item,
+		markdown: components.NewMarkdownModel("", keyMap),
+		nav:      config.NewNavigationManager(),
+		keyMap:   keyMap,
+	}
+	m.buttons = components.NewButtonBarModel(m.nav, 0, keyMap, func(index int) tea.Msg {
+		return detailActionMsg{origin: origin, action: detailAction(index)}
+	}, "Edit", "Delete", "Duplicate", "Back").WithBack(int(detailBack))
+	// Enter right away goes back, it can't change anything by accident
+	m.buttons.Focus(int(detailBack))
+	m.markdown.SetContent(itemDescription(m.item))
+	return m
+}
+
+// Item returns the item being shown
+func (m ItemDetailModel) Item() actions.DataItem {
+	return m.item
+}
+
+// SetItem shows the latest version of the item
+func (m *ItemDetailModel) SetItem(item actions.DataItem) {
+	if item.Equal(m.item) {
+		return
+	}
+	m.item = item
+	m.markdown.SetContent(itemDescription(m.item))
+}
+
+// SetSize sets the area the detail renders into
+func (m *ItemDetailModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	body := m.layout().Layout(width, height)["description"]
+	m.markdown.SetSize(body.Width, body.Height)
+}
+
+// layout puts the title and metadata above the description and the buttons
+// below it
+func (m ItemDetailModel) layout() layout.Flex {
+	return layout.Column(
+		layout.Fixed("title", 1),
+		layout.Fixed("meta", 1),
+		layout.Flexible("description", 1),
+		layout.Fixed("buttons", 3),
+	)
+}
+
+func (m ItemDetailModel) Update(msg tea.Msg) (ItemDetailModel, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case tea.MouseMsg:
+		if cmd, ok := m.buttons.HandleMouse(msg); ok {
+			return m, cmd
+		}
+		// The wheel scrolls the description
+		m.markdown, cmd = m.markdown.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m ItemDetailModel) handleKey(msg tea.KeyMsg) (ItemDetailModel, tea.Cmd) {
+	if cmd, ok := m.buttons.HandleKey(msg); ok {
+		return m, cmd
+	}
+	switch {
+	case key.Matches(msg, m.keyMap.Edit):
+		return m, m.buttons.Press(int(detailEdit))
+	case key.Matches(msg, m.keyMap.Delete):
+		return m, m.buttons.Press(int(detailDelete))
+	case key.Matches(msg, m.keyMap.Duplicate):
+		return m, m.buttons.Press(int(detailDuplicate))
+	case key.Matches(msg, m.keyMap.Copy):
+		return m, func() tea.Msg {
+			return detailActionMsg{origin: m.origin, action: detailCopy}
+		}
+
+	// Up and down scroll, the other navigation keys move between buttons
+	case key.Matches(msg, m.keyMap.Up), key.Matches(msg, m.keyMap.Down):
+		var cmd tea.Cmd
+		m.markdown, cmd = m.markdown.Update(msg)
+		return m, cmd
+	}
+
+	if !m.nav.HandleKey(msg, m.keyMap) {
+		var cmd tea.Cmd
+		m.markdown, cmd = m.markdown.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m ItemDetailModel) View() string {
+	return m.layout().Render(m.width, m.height, map[string]string{
+		"title":       itemTitleView(m.item),
+		"meta":        itemMetaView(m.item),
+		"description": m.markdown.View(),
+		"buttons":     m.buttons.View(),
+	})
+}
+
+// itemTitleView renders the title of an item above its details
+func itemTitleView(d actions.DataItem) string {
+	return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFFFFF")).PaddingLeft(1).
+		Render(d.Title)
+}
+
+// itemMetaView renders the status, date and ID of an item on one line
+func itemMetaView(d actions.DataItem) string {
+	label := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	fields := []string{
+		label.Render("Status ") + statusNames[d.ItemStatus()],
+	}
+	if !d.Created.IsZero() {
+		fields = append(fields, label.Render("Added ")+d.Created.Local().Format("Mon 2 Jan 2006 15:04"))
+	}
+	fields = append(fields, label.Render("ID ")+d.ID)
+	return lipgloss.NewStyle().PaddingLeft(1).Render(strings.Join(fields, label.Render(" • ")))
+}
+
+// itemDescription returns the Markdown description of an item, with a note
+// when there is none
+func itemDescription(d actions.DataItem) string {
+	if strings.TrimSpace(d.Description) == "" {
+		return "*No description*"
+	}
+	return d.Description
+}
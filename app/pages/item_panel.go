@@ -0,0 +1,107 @@
+// app/pages/item_panel.go
+package pages
+
+import (
+	"bubbletea-app/app/actions"
+	"bubbletea-app/app/components"
+	"bubbletea-app/app/config"
+	"bubbletea-app/app/global"
+	"bubbletea-app/app/layout"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ItemPanelModel shows the item under the cursor of the Home list, next to
+// it in the workspace. It follows the list through ItemSelectedMsg, and its
+// description scrolls with the keys while the panel has focus
+type ItemPanelModel struct {
+	header    components.HeaderModel
+	item      actions.DataItem
+	selected  bool
+	markdown  components.MarkdownModel
+	textFocus *components.FocusRegion
+	nav       *config.NavigationManager
+	width     int
+	height    int
+}
+
+// NewItemPanelModel creates a panel with nothing selected yet
+func NewItemPanelModel(keyMap config.KeyMap) ItemPanelModel {
+	textFocus := components.NewFocusRegion("text")
+	nav := config.NewNavigationManager()
+	nav.AddItem(textFocus)
+	nav.Focus()
+
+	return ItemPanelModel{
+		header:    components.NewHeaderModel("Item"),
+		markdown:  components.NewMarkdownModel("", keyMap),
+		textFocus: textFocus,
+		nav:       nav,
+	}
+}
+
+func (m ItemPanelModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ItemPanelModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.resize()
+
+	case ItemSelectedMsg:
+		changed := msg.Selected != m.selected || !msg.Item.Equal(m.item)
+		m.item, m.selected = msg.Item, msg.Selected
+		if changed {
+			m.markdown.SetContent(itemDescription(m.item))
+		}
+
+	case global.PageFocusChangedMsg:
+		if msg {
+			m.nav.Focus()
+		} else {
+			m.nav.Blur()
+		}
+
+	case tea.KeyMsg:
+		if m.textFocus.IsFocused() {
+			m.markdown, cmd = m.markdown.Update(msg)
+		}
+
+	case tea.MouseMsg:
+		m.markdown, cmd = m.markdown.Update(msg)
+	}
+	return m, cmd
+}
+
+// layout puts the title and metadata of the item above its description
+func (m ItemPanelModel) layout() layout.Flex {
+	return layout.Column(
+		layout.Fixed("header", lipgloss.Height(m.header.View(m.width))),
+		layout.Fixed("title", 1),
+		layout.Fixed("meta", 1),
+		layout.Flexible("description", 1).WithMin(1),
+	)
+}
+
+func (m *ItemPanelModel) resize() {
+	body := m.layout().Layout(m.width, m.height)["description"]
+	m.markdown.SetSize(body.Width, body.Height)
+}
+
+func (m ItemPanelModel) View() string {
+	views := map[string]string{"header": m.header.View(m.width)}
+	if !m.selected {
+		views["title"] = lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")).PaddingLeft(1).
+			Render("No item selected")
+	} else {
+		views["title"] = itemTitleView(m.item)
+		views["meta"] = itemMetaView(m.item)
+		views["description"] = m.markdown.View()
+	}
+	return m.layout().Render(m.width, m.height, views)
+}
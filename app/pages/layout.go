@@ -0,0 +1,23 @@
+// app/pages/layout.go
+package pages
+
+import (
+	"bubbletea-app/app/layout"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pageLayout stacks the header, the page content and the footer, with the
+// content taking whatever height is left. Short or narrow terminals drop the
+// footer
+func pageLayout(header, footer string) layout.Responsive {
+	headerRegion := layout.Fixed("header", lipgloss.Height(header))
+	content := layout.Flexible("content", 1)
+	withoutFooter := layout.Column(headerRegion, content)
+
+	return layout.NewResponsive(
+		layout.Column(headerRegion, content, layout.Fixed("footer", lipgloss.Height(footer))),
+		layout.Breakpoint{MaxWidth: layout.CompactWidth, Flex: withoutFooter},
+		layout.Breakpoint{MaxHeight: 16, Flex: withoutFooter},
+	)
+}
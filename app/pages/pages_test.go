@@ -0,0 +1,180 @@
+// app/pages/pages_test.go
+package pages_test
+
+import (
+	"bubbletea-app/app/actions"
+	"bubbletea-app/app/actions/standin"
+	"bubbletea-app/app/apptest"
+	"bubbletea-app/app/config"
+	"bubbletea-app/app/global"
+	"bubbletea-app/app/pages"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// testItems are the items the Home tests start with
+var testItems = []actions.DataItem{
+	{ID: "1", Title: "Write the report", Description: "Numbers from **Q3**", Status: "doing", Created: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)},
+	{ID: "2", Title: "Book the venue", Status: "todo", Created: time.Date(2025, 1, 7, 9, 0, 0, 0, time.UTC)},
+	{ID: "3", Title: "Send invites", Description: "After the venue is booked", Status: "done", Created: time.Date(2025, 1, 8, 9, 0, 0, 0, time.UTC)},
+}
+
+func TestMain(m *testing.M) {
+	// Dates show in local time
+	time.Local = time.UTC
+	os.Exit(m.Run())
+}
+
+// setup gives the test a config dir of its own to work in, and returns the
+// settings for a file backend holding testItems
+func setup(t *testing.T) config.Settings {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", home)
+	t.Chdir(home)
+
+	settings := config.Settings{Backend: config.BackendFile, DataPath: "items.json"}
+	if err := actions.ExportItems(settings.DataPath, testItems); err != nil {
+		t.Fatal(err)
+	}
+	return settings
+}
+
+// newHome starts the Home page on the backend in settings, with the clock
+// masked
+func newHome(t *testing.T, settings config.Settings, width, height int) *apptest.Harness {
+	t.Helper()
+	store := actions.NewStore(settings)
+	t.Cleanup(func() { store.Close() })
+
+	// Pages start hidden, the app shows the first one
+	return apptest.New(t, pages.NewHomeModel(config.DefaultKeyMap(), store), width, height).
+		Send(global.PageVisibleMsg(true)).
+		Mask(`\d\d:\d\d:\d\d`, "12:00:00")
+}
+
+func TestHome(t *testing.T) {
+	settings := setup(t)
+	h := newHome(t, settings, 80, 24)
+	h.Golden("home")
+
+	h.Press("j").Golden("home_cursor")
+	h.Press("enter").Golden("home_detail")
+	h.Press("esc", "o", "o", "o").Golden("home_sorted_by_status")
+}
+
+func TestHomeSearch(t *testing.T) {
+	h := newHome(t, setup(t), 80, 24)
+	// The app turns / into a search message for the page
+	h.Send(global.SearchMsg{}).Type("venue").Golden("home_search")
+	h.Press("enter").Golden("home_filtered")
+	h.Press("esc").Golden("home_search_cleared")
+}
+
+func TestHomeForm(t *testing.T) {
+	h := newHome(t, setup(t), 80, 24)
+	h.Press("a").Type("Order flowers").Golden("home_add")
+	h.Press("enter", "enter").Golden("home_added")
+	h.Press("u").Golden("home_add_undone")
+}
+
+func TestHomeServerIDs(t *testing.T) {
+	setup(t)
+	server := standin.New("", testItems...)
+	t.Cleanup(server.Close)
+	server.AssignIDs(true)
+	h := newHome(t, server.Settings(), 80, 24)
+
+	// The event for the new item comes in before the answer to the POST
+	h.Hold(func(msg tea.Msg) bool {
+		_, ok := msg.(actions.MutationDoneMsg)
+		return ok
+	})
+	h.Press("a").Type("Order flowers").Press("enter", "enter")
+	created, err := server.Source().Get(context.Background(), "server-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Send(actions.StreamEventMsg{Event: actions.StreamEvent{Type: actions.EventCreated, Item: created}})
+	h.Release()
+
+	if n := strings.Count(h.View(), "Order flowers  todo"); n != 1 {
+		t.Errorf("the new item shows %d times:\n%s", n, h.View())
+	}
+}
+
+func TestHomeOffline(t *testing.T) {
+	setup(t)
+	server := standin.New("", testItems...)
+	t.Cleanup(server.Close)
+	store := actions.NewStore(server.Settings())
+	t.Cleanup(func() { store.Close() })
+	source, _ := store.Source()
+	client := source.(*actions.Client)
+	client.Retry, client.Breaker = actions.NoRetry, nil
+
+	h := apptest.New(t, pages.NewHomeModel(config.DefaultKeyMap(), store), 80, 24).
+		Send(global.PageVisibleMsg(true))
+	server.Close()
+	h.Wait = time.Second
+	h.Press("r")
+
+	view := h.View()
+	for _, want := range []string{"Write the report", "Book the venue", "Send invites", "Offline — showing cached data from"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("%q is missing from\n%s", want, view)
+		}
+	}
+}
+
+func TestHomeCompact(t *testing.T) {
+	h := newHome(t, setup(t), 44, 14)
+	h.Golden("home_compact")
+}
+
+func TestHomeFailed(t *testing.T) {
+	settings := setup(t)
+	if err := os.WriteFile(settings.DataPath, []byte("not JSON"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	newHome(t, settings, 80, 24).Golden("home_failed")
+}
+
+func TestSettings(t *testing.T) {
+	setup(t)
+	h := apptest.New(t, pages.NewSettingsModel(config.DefaultKeyMap()), 80, 40)
+	h.Golden("settings")
+
+	// The file backend only asks for the data file
+	h.Press("right").Golden("settings_file")
+	h.Press("down", "enter").Type("items.json").Golden("settings_editing")
+	h.Press("enter").Golden("settings_edited")
+}
+
+func TestAbout(t *testing.T) {
+	setup(t)
+	h := apptest.New(t, pages.NewAboutModel(config.DefaultKeyMap()), 80, 24)
+	h.Golden("about")
+	h.Press("pgdown").Golden("about_scrolled")
+}
+
+func TestHomeHidden(t *testing.T) {
+	store := actions.NewStore(setup(t))
+	t.Cleanup(func() { store.Close() })
+
+	// A page that was never shown fetches nothing
+	h := apptest.New(t, pages.NewHomeModel(config.DefaultKeyMap(), store), 80, 24)
+	if view := h.View(); !strings.Contains(view, "Loading data…") {
+		t.Fatalf("the hidden page loaded:\n%s", view)
+	}
+	h.Send(global.PageVisibleMsg(true))
+	if view := h.View(); !strings.Contains(view, "Write the report") {
+		t.Fatalf("the page didn't load once shown:\n%s", view)
+	}
+}
@@ -2,50 +2,118 @@
 package pages
 
 import (
+	"bubbletea-app/app/actions"
 	"bubbletea-app/app/components"
 	"bubbletea-app/app/config"
 	"bubbletea-app/app/global"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
 )
 
+// inputWidth is the width of the settings inputs when there is enough room
+const inputWidth = 30
+
+// Indexes of the inputs
+const (
+	hostField = iota
+	portField
+	apiKeyField
+	dataPathField
+	refreshField
+)
+
+// inputLabels name the inputs, by index
+var inputLabels = []string{"Host:", "Port:", "API Key:", "Data file:", "Refresh every:"}
+
 type SettingsModel struct {
-	inputs     []textinput.Model
-	buttons    []components.ButtonModel
-	header     components.HeaderModel
-	footer     components.FooterModel
-	keyMap     config.KeyMap
-	width      int
-	height     int
-	focusIndex int // tracks which element has focus
-	focusables int // total focusable elements
+	id      string
+	backend *components.SelectModel
+	inputs  []textinput.Model
+	fields  []*components.InputWrapper
+	buttons []components.ButtonModel
+	nav     *config.NavigationManager
+	header  components.HeaderModel
+	footer  components.FooterModel
+	keyMap  config.KeyMap
+	status  string
+	failed  bool
+	width   int
+	height  int
 }
 
 func NewSettingsModel(keyMap config.KeyMap) SettingsModel {
 	// Create inputs
 	hostInput := textinput.New()
 	hostInput.Placeholder = "Enter host (e.g., localhost)"
-	hostInput.Width = 30
+	hostInput.Width = inputWidth
 	hostInput.Blur()
 
 	portInput := textinput.New()
 	portInput.Placeholder = "Enter port (e.g., 8080)"
-	portInput.Width = 30
+	portInput.Width = inputWidth
 	portInput.Blur()
 
 	apiKeyInput := textinput.New()
 	apiKeyInput.Placeholder = "Enter API key"
-	apiKeyInput.Width = 30
+	apiKeyInput.Width = inputWidth
 	apiKeyInput.Blur()
 
-	// Create button with save action
+	dataPathInput := textinput.New()
+	dataPathInput.Placeholder = "Empty for the default location"
+	dataPathInput.Width = inputWidth
+	dataPathInput.Blur()
+
+	refreshInput := textinput.New()
+	refreshInput.Placeholder = "Seconds, empty turns it off"
+	refreshInput.Width = inputWidth
+	refreshInput.Blur()
+
+	backend := components.NewSelectModel(config.Backends...)
+
+	// Start from the saved settings, if any
+	var status string
+	settings, err := config.LoadSettings()
+	if err != nil {
+		status = fmt.Sprintf("Could not load settings: %v", err)
+	}
+	backend.SetValue(settings.DataBackend())
+	hostInput.SetValue(settings.Host)
+	portInput.SetValue(settings.Port)
+	apiKeyInput.SetValue(settings.APIKey)
+	dataPathInput.SetValue(settings.DataPath)
+	refreshInput.SetValue(refreshValue(settings.RefreshInterval))
+
+	// Create button with save action. It reads the inputs through the slice,
+	// so it sees what was typed after the button was created
+	inputs := []textinput.Model{hostInput, portInput, apiKeyInput, dataPathInput, refreshInput}
+	for i := range inputs {
+		inputs[i].KeyMap.Paste = keyMap.Paste
+	}
 	saveButton := components.NewButtonModel("Save Configuration", func() tea.Msg {
-		fmt.Println("Config saved:", hostInput.Value(), portInput.Value(), apiKeyInput.Value())
-		return nil
+		return SaveSettingsMsg{
+			Backend:         backend.Value(),
+			Host:            strings.TrimSpace(inputs[hostField].Value()),
+			Port:            strings.TrimSpace(inputs[portField].Value()),
+			APIKey:          strings.TrimSpace(inputs[apiKeyField].Value()),
+			DataPath:        strings.TrimSpace(inputs[dataPathField].Value()),
+			RefreshInterval: strings.TrimSpace(inputs[refreshField].Value()),
+		}
+	})
+	clearButton := components.NewButtonModel("Clear Cache", func() tea.Msg {
+		return global.SpawnModalMsg{
+			Title:       "Clear cache?",
+			Description: "Items fetched from the API won't be shown offline until they are fetched again.",
+			OnConfirm: func() tea.Msg {
+				return cacheClearedMsg{err: actions.ClearCache()}
+			},
+		}
 	})
 	leaveButton := components.NewButtonModel("QUIT!", func() tea.Msg {
 		return global.SpawnModalMsg{
@@ -63,14 +131,51 @@ func NewSettingsModel(keyMap config.KeyMap) SettingsModel {
 	},
 	)
 
-	return SettingsModel{
-		inputs:     []textinput.Model{hostInput, portInput, apiKeyInput},
-		buttons:    []components.ButtonModel{saveButton, leaveButton},
-		header:     components.NewHeaderModel("Settings"),
-		keyMap:     keyMap,
-		focusIndex: 0,
-		focusables: 4, // 3 inputs + 1 button
+	m := SettingsModel{
+		id:      zone.NewPrefix(),
+		backend: backend,
+		inputs:  inputs,
+		buttons: []components.ButtonModel{saveButton, clearButton, leaveButton},
+		nav:     config.NewNavigationManager(),
+		header:  components.NewHeaderModel("Settings"),
+		keyMap:  keyMap,
+		status:  status,
+		failed:  err != nil,
+	}
+
+	// Inputs form a column and buttons a row, each in its own focus group.
+	// The wrappers point into the slices above, which every copy of the
+	// model shares
+	inputGroup := config.NewNavigationManager()
+	inputGroup.AddItem(backend)
+	for i := range m.inputs {
+		field := components.NewInputWrapper(&m.inputs[i])
+		m.fields = append(m.fields, field)
+		inputGroup.AddItem(field)
 	}
+	buttonGroup := config.NewNavigationManager()
+	for i := range m.buttons {
+		buttonGroup.AddItemAt(&m.buttons[i], 0, i)
+	}
+	m.nav.AddItem(inputGroup)
+	m.nav.AddItem(buttonGroup)
+	m.updateFields()
+	m.nav.Focus()
+
+	return m
+}
+
+// updateFields disables the inputs the picked backend doesn't use, so
+// navigation skips them
+func (m SettingsModel) updateFields() {
+	backend := m.backend.Value()
+	remote := backend == config.BackendREST
+	local := backend == config.BackendFile || backend == config.BackendSQLite
+
+	m.fields[hostField].SetDisabled(!remote)
+	m.fields[portField].SetDisabled(!remote)
+	m.fields[apiKeyField].SetDisabled(!remote)
+	m.fields[dataPathField].SetDisabled(!local)
 }
 
 func (m SettingsModel) Init() tea.Cmd {
@@ -85,13 +190,82 @@ func (m SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 
+		// Leave room for the prompt, the border and the padding
+		for i := range m.inputs {
+			m.inputs[i].Width = max(min(inputWidth, m.width-8), 1)
+		}
+
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
+	case SaveSettingsMsg:
+		var interval int
+		if msg.RefreshInterval != "" {
+			var err error
+			if interval, err = strconv.Atoi(msg.RefreshInterval); err != nil || interval < 0 {
+				m.status = fmt.Sprintf("Could not save: invalid refresh interval %q: must be a number of seconds", msg.RefreshInterval)
+				m.failed = true
+				return m, nil
+			}
+		}
+		return m, saveSettings(config.Settings{
+			Backend:         msg.Backend,
+			Host:            msg.Host,
+			Port:            msg.Port,
+			APIKey:          msg.APIKey,
+			DataPath:        msg.DataPath,
+			RefreshInterval: interval,
+		})
+
+	case settingsSaveFailedMsg:
+		m.status = fmt.Sprintf("Could not save: %v", msg.err)
+		m.failed = true
+
+	case actions.CopiedMsg:
+		if msg.Origin != m.id {
+			break
+		}
+		m.status = copiedStatus(msg.What, msg.ViaTerminal)
+		m.failed = msg.Err != nil
+		if m.failed {
+			m.status = fmt.Sprintf("Could not copy: %v", msg.Err)
+		}
+
+	case cacheClearedMsg:
+		m.status = "Cache cleared"
+		m.failed = msg.err != nil
+		if m.failed {
+			m.status = fmt.Sprintf("Could not clear cache: %v", msg.err)
+		}
+
+	case global.SettingsChangedMsg:
+		m.status = "Config saved"
+		m.failed = false
+		if !m.editing() {
+			m.backend.SetValue(msg.Settings.DataBackend())
+			m.inputs[hostField].SetValue(msg.Settings.Host)
+			m.inputs[portField].SetValue(msg.Settings.Port)
+			m.inputs[apiKeyField].SetValue(msg.Settings.APIKey)
+			m.inputs[dataPathField].SetValue(msg.Settings.DataPath)
+			m.inputs[refreshField].SetValue(refreshValue(msg.Settings.RefreshInterval))
+			m.updateFields()
+		}
+
+	case global.PageFocusChangedMsg:
+		// Remember the focused element while away and restore it on return
+		if msg {
+			m.nav.Focus()
+		} else {
+			m.nav.Blur()
+		}
+
 	case tea.KeyMsg:
 
 		// Handle already focused inputs first
-		for i, input := range m.inputs {
-			if input.Focused() {
+		for i, field := range m.fields {
+			if field.IsEditing() {
 				if key.Matches(msg, m.keyMap.Esc) || key.Matches(msg, m.keyMap.Enter) {
-					m.inputs[i].Blur()
+					field.StopEditing()
 					return m, func() tea.Msg {
 						return global.InputFocusChangedMsg(false)
 					}
@@ -102,54 +276,43 @@ func (m SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		// Navigation when no inputs are focused
-		switch {
-		case key.Matches(msg, m.keyMap.Down) || msg.String() == "j":
-			m.focusIndex = (m.focusIndex + 1) % m.focusables
-			updateFocusState(&m)
-			return m, nil
-
-		case key.Matches(msg, m.keyMap.Up) || msg.String() == "k":
-			m.focusIndex = (m.focusIndex - 1 + m.focusables) % m.focusables
-			updateFocusState(&m)
-			return m, nil
-
-		case key.Matches(msg, m.keyMap.Left) || msg.String() == "h":
-			// Only navigate left when on a button
-			if m.focusIndex >= len(m.inputs) {
-				buttonIndex := m.focusIndex - len(m.inputs)
-				if buttonIndex > 0 {
-					m.focusIndex--
-					updateFocusState(&m)
-				}
+		// Left and right flip through the backends
+		if m.backend.IsFocused() {
+			switch {
+			case key.Matches(msg, m.keyMap.Left):
+				m.backend.Previous()
+				m.updateFields()
+				return m, nil
+			case key.Matches(msg, m.keyMap.Right), key.Matches(msg, m.keyMap.Enter):
+				m.backend.Next()
+				m.updateFields()
+				return m, nil
 			}
-			return m, nil
+		}
 
-		case key.Matches(msg, m.keyMap.Right) || msg.String() == "l":
-			// Only navigate right when on a button
-			if m.focusIndex >= len(m.inputs) {
-				buttonIndex := m.focusIndex - len(m.inputs)
-				if buttonIndex < len(m.buttons)-1 {
-					m.focusIndex++
-					updateFocusState(&m)
-				}
-			}
+		// Navigation when no inputs are focused
+		if m.nav.HandleKey(msg, m.keyMap) {
 			return m, nil
+		}
 
-		case key.Matches(msg, m.keyMap.Enter):
-			// Toggle input focus or activate button
-			if m.focusIndex < len(m.inputs) {
-				m.inputs[m.focusIndex].Focus()
+		if key.Matches(msg, m.keyMap.Copy) {
+			return m.copyField()
+		}
+
+		if key.Matches(msg, m.keyMap.Enter) {
+			// Start editing the selected input or activate the button
+			switch item := m.nav.Leaf().(type) {
+			case *components.InputWrapper:
+				item.Edit()
 				return m, tea.Batch(
 					textinput.Blink,
 					func() tea.Msg {
 						return global.InputFocusChangedMsg(true)
 					},
 				)
-			} else {
-				buttonIndex := m.focusIndex - len(m.inputs)
-				if buttonIndex < len(m.buttons) {
-					return m, m.buttons[buttonIndex].OnClick
+			case *components.ButtonModel:
+				if !item.IsDisabled() {
+					return m, item.OnClick
 				}
 			}
 		}
@@ -160,17 +323,88 @@ func (m SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // Message sent when save button is clicked
 type SaveSettingsMsg struct {
-	Host   string
-	Port   string
-	APIKey string
+	Backend  string
+	Host     string
+	Port     string
+	APIKey   string
+	DataPath string
+	// RefreshInterval is in seconds, as typed
+	RefreshInterval string
+}
+
+// refreshValue shows a refresh interval in its input, empty when off
+func refreshValue(seconds int) string {
+	if seconds <= 0 {
+		return ""
+	}
+	return strconv.Itoa(seconds)
+}
+
+// settingsSaveFailedMsg reports settings that could not be written
+type settingsSaveFailedMsg struct {
+	err error
+}
+
+// cacheClearedMsg reports how clearing the cache went
+type cacheClearedMsg struct {
+	err error
+}
+
+// saveSettings writes the settings in the background and announces them to
+// every page once they are on disk
+func saveSettings(settings config.Settings) tea.Cmd {
+	return func() tea.Msg {
+		if err := config.SaveSettings(settings); err != nil {
+			return settingsSaveFailedMsg{err: err}
+		}
+		return global.SettingsChangedMsg{Settings: settings}
+	}
+}
+
+// copyField copies the value of the selected input to the clipboard
+func (m SettingsModel) copyField() (tea.Model, tea.Cmd) {
+	for i, field := range m.fields {
+		if !field.IsFocused() {
+			continue
+		}
+		name := strings.TrimSuffix(inputLabels[i], ":")
+		if m.inputs[i].Value() == "" {
+			m.status = fmt.Sprintf("Nothing to copy, %s is empty", name)
+			m.failed = true
+			return m, nil
+		}
+		return m, actions.CopyCmd(m.id, name, m.inputs[i].Value())
+	}
+	return m, nil
+}
+
+// editing reports whether one of the inputs is being edited
+func (m SettingsModel) editing() bool {
+	for _, field := range m.fields {
+		if field.IsEditing() {
+			return true
+		}
+	}
+	return false
 }
 
 func (m SettingsModel) View() string {
-	inputsView := ""
-	inputLabels := []string{"Host:", "Port:", "API Key:"}
+	backendLabel := lipgloss.NewStyle().
+		Bold(m.backend.IsFocused()).
+		Foreground(map[bool]lipgloss.Color{
+			true:  lipgloss.Color("#25A065"),
+			false: lipgloss.Color("#888888"),
+		}[m.backend.IsFocused()]).
+		Render("Backend:")
+	inputsView := fmt.Sprintf("%s %s\n\n", backendLabel, m.backend.View())
+
+	// Only the inputs the backend uses are shown
 	for i, input := range m.inputs {
-		selected := m.focusIndex == i
-		editing := input.Focused()
+		if m.fields[i].IsDisabled() {
+			continue
+		}
+		selected := m.fields[i].IsFocused()
+		editing := m.fields[i].IsEditing()
 		label := lipgloss.NewStyle().
 			Bold(selected).
 			Foreground(map[bool]lipgloss.Color{
@@ -192,7 +426,7 @@ func (m SettingsModel) View() string {
 				Padding(0, 1).
 				Render(inputBox)
 		}
-		inputsView += fmt.Sprintf("%s\n%s\n\n", label, inputBox)
+		inputsView += fmt.Sprintf("%s\n%s\n\n", label, zone.Mark(m.inputID(i), inputBox))
 	}
 
 	// Render buttons
@@ -207,58 +441,94 @@ func (m SettingsModel) View() string {
 
 	// Navigation help
 	var navHelp string
-	if m.focusIndex < len(m.inputs) && m.inputs[m.focusIndex].Focused() {
+	if field, ok := m.nav.Leaf().(*components.InputWrapper); ok && field.IsEditing() {
 		navHelp = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#FF6700")).
-			Render("EDIT MODE: Press ESC to exit editing or ENTER to submit")
+			Render(fmt.Sprintf("EDIT MODE: Press ESC to exit editing or ENTER to submit • %s pastes", m.keyMap.Paste.Help().Key))
 	} else {
 		navHelp = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#888888")).
-			Render("Navigate with ↑↓ or j/k • Press Enter to edit/select")
+			Render(fmt.Sprintf("Navigate with ↑↓←→, hjkl or tab • Press Enter to edit/select, %s to copy", m.keyMap.Copy.Help().Key))
 	}
 
-	// Combine form content
-	mainContent := lipgloss.JoinVertical(
-		lipgloss.Left,
-		inputsView,
-		buttonsContainer,
-		navHelp,
-	)
+	// Result of the last save
+	statusColor := lipgloss.Color("#25A065")
+	if m.failed {
+		statusColor = lipgloss.Color("#F44336")
+	}
+	statusView := lipgloss.NewStyle().Foreground(statusColor).Render(m.status)
+
+	// Combine form content, the status line only takes room once there is
+	// something to report
+	rows := []string{strings.TrimSuffix(inputsView, "\n"), buttonsContainer}
+	if m.status != "" {
+		rows = append(rows, statusView)
+	}
+	rows = append(rows, navHelp)
+	mainContent := lipgloss.JoinVertical(lipgloss.Left, rows...)
 
 	headerView := m.header.View(m.width)
 	footerView := m.footer.View(m.width)
 
-	// Calculate available space first
-	availableHeight := m.height - lipgloss.Height(headerView) - lipgloss.Height(footerView) - 2
-
-	// Stretch the content to fill available space
-	stretchedContent := lipgloss.NewStyle().
-		Height(availableHeight).
-		Render(mainContent)
-	fullContent := fmt.Sprintf(
-		"%s\n%s\n%s",
-		headerView,
-		stretchedContent,
-		footerView,
-	)
-
-	return fullContent
+	// The content stretches to fill the space between header and footer
+	return pageLayout(headerView, footerView).Render(m.width, m.height, map[string]string{
+		"header":  headerView,
+		"content": mainContent,
+		"footer":  footerView,
+	})
 }
 
-func updateFocusState(m *SettingsModel) {
-	// Clear focus on all elements
-	for i := range m.inputs {
-		m.inputs[i].Blur()
+// handleMouse focuses clicked inputs and presses clicked buttons
+func (m SettingsModel) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+		return m, nil
+	}
+
+	// Leaving an input by clicking somewhere else ends editing
+	wasEditing := m.editing()
+
+	if m.backend.HandleMouse(msg) {
+		m.nav.FocusItem(m.backend)
+		m.updateFields()
+	}
+
+	for i, field := range m.fields {
+		if !field.IsDisabled() && components.Clicked(m.inputID(i), msg) {
+			m.nav.FocusItem(field)
+			field.Edit()
+			return m, tea.Batch(
+				textinput.Blink,
+				func() tea.Msg {
+					return global.InputFocusChangedMsg(true)
+				},
+			)
+		}
 	}
+
+	var cmds []tea.Cmd
 	for i := range m.buttons {
-		m.buttons[i].Blur()
+		if m.buttons[i].Clicked(msg) {
+			m.nav.FocusItem(&m.buttons[i])
+			var cmd tea.Cmd
+			m.buttons[i], cmd = m.buttons[i].Update(msg)
+			cmds = append(cmds, cmd)
+			break
+		}
 	}
 
-	// Set focus only on buttons when they're selected
-	if m.focusIndex >= len(m.inputs) {
-		buttonIndex := m.focusIndex - len(m.inputs)
-		if buttonIndex < len(m.buttons) {
-			m.buttons[buttonIndex].Focus()
+	if wasEditing {
+		for _, field := range m.fields {
+			field.StopEditing()
 		}
+		cmds = append(cmds, func() tea.Msg {
+			return global.InputFocusChangedMsg(false)
+		})
 	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// inputID returns the zone id of the input at index
+func (m SettingsModel) inputID(index int) string {
+	return fmt.Sprintf("%sinput-%d", m.id, index)
 }
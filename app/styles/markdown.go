@@ -0,0 +1,26 @@
+package styles
+
+import (
+	"github.com/charmbracelet/glamour/ansi"
+	glamourstyles "github.com/charmbracelet/glamour/styles"
+)
+
+// MarkdownStyle returns the Markdown style for a dark or light terminal,
+// with headings and links in the colors of the app
+func MarkdownStyle(dark bool) ansi.StyleConfig {
+	style := glamourstyles.LightStyleConfig
+	if dark {
+		style = glamourstyles.DarkStyleConfig
+	}
+
+	style.H1.BackgroundColor = color("#5A56E0")
+	style.H1.Color = color("#FFFFFF")
+	style.Heading.Color = color("#25A065")
+	style.Link.Color = color("#25A065")
+	style.LinkText.Color = color("#25A065")
+	return style
+}
+
+func color(c string) *string {
+	return &c
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bubbletea-app/app/actions"
+	"bubbletea-app/app/config"
+	"bubbletea-app/app/debug"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// commands are run from the command line instead of the app, as in
+// `bubbletea-app export -o items.csv`
+var commands = map[string]func(args []string) error{
+	"export": exportCommand,
+	"debug":  debugCommand,
+}
+
+// runCommand runs the command named by the first of args
+func runCommand(args []string) error {
+	command, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q, try export or debug", args[0])
+	}
+	err := command(args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	return err
+}
+
+// exportCommand writes the items of the backend selected in Settings to a
+// file or standard output, like the export action on Home does
+func exportCommand(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flags.String("format", "", "json, csv or md, picked by the extension of -o by default, else json")
+	output := flags.String("o", "", "`file` to write, standard output when not set")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	exportFormat := actions.FormatJSON
+	if *format != "" {
+		var err error
+		if exportFormat, err = actions.ParseExportFormat(*format); err != nil {
+			return err
+		}
+	}
+	if *output != "" {
+		fileFormat, err := actions.FormatOf(*output)
+		if err != nil {
+			return err
+		}
+		if *format != "" && fileFormat != exportFormat {
+			return fmt.Errorf("-format %s doesn't match %s", *format, *output)
+		}
+	}
+
+	settings, err := config.LoadSettings()
+	if err != nil {
+		return err
+	}
+	items, err := actions.FetchData(settings)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return actions.WriteItems(os.Stdout, exportFormat, items)
+	}
+	if err := actions.ExportItems(*output, items); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d items to %s\n", len(items), *output)
+	return nil
+}
+
+// debugCommand runs the app recording every message that reaches it, with
+// the Debug key opening an overlay to step through them. See debug.Model
+func debugCommand(args []string) error {
+	flags := flag.NewFlagSet("debug", flag.ContinueOnError)
+	output := flags.String("o", "", "`file` the recording is exported to, also when the app quits. A new file in the config dir by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	final, err := runApp(func(model appModel) tea.Model {
+		return debug.New(model, model.keyMap, *output)
+	})
+	if err != nil || *output == "" {
+		return err
+	}
+	recording := final.(debug.Model).Recording()
+	if err := recording.Save(*output); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Recorded %d messages to %s\n", len(recording.Records), *output)
+	return nil
+}
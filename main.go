@@ -1,16 +1,22 @@
 package main
 
 import (
+	"bubbletea-app/app/actions"
 	"bubbletea-app/app/components"
 	"bubbletea-app/app/config"
+	"bubbletea-app/app/debug"
 	"bubbletea-app/app/global"
+	"bubbletea-app/app/layout"
 	"bubbletea-app/app/pages"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
 	"golang.org/x/term"
 )
 
@@ -18,9 +24,12 @@ type (
 	appModel struct {
 		currentPage      string
 		modalModel       components.ModalModel
-		homeModel        pages.HomeModel
 		settingsModel    pages.SettingsModel
 		aboutModel       pages.AboutModel
+		workspaceModel   components.SplitPaneModel
+		store            *actions.Store
+		stream           *actions.Stream
+		streamState      actions.StreamState
 		keyMap           config.KeyMap
 		width            int
 		height           int
@@ -39,37 +48,79 @@ func initialModel() appModel {
 	}
 	width, height, _ := term.GetSize(0)
 
+	// Both Home pages share one data store. A broken settings file falls
+	// back to the defaults, the Settings page shows the error
+	settings, _ := config.LoadSettings()
+	store := actions.NewStore(settings)
+
 	return appModel{
 		currentPage:   "home",
 		modalModel:    components.NewModal("", ""),
-		homeModel:     pages.NewHomeModel(keyMap),
 		settingsModel: pages.NewSettingsModel(keyMap),
-		aboutModel:    pages.NewAboutModel(),
-		keyMap:        keyMap,
-		showHelp:      false,
-		width:         width,
-		height:        height,
+		aboutModel:    pages.NewAboutModel(keyMap),
+		// The Home page is the list pane of the workspace on its own, so
+		// both pages share one Home and the data it loads
+		workspaceModel: components.NewSplitPane(
+			"workspace",
+			layout.Horizontal,
+			pages.NewHomeModel(keyMap, store),
+			pages.NewItemPanelModel(keyMap),
+			keyMap,
+		),
+		store:    store,
+		stream:   actions.NewStream(settings),
+		keyMap:   keyMap,
+		showHelp: false,
+		width:    width,
+		height:   height,
 	}
 }
 
 func (m appModel) Init() tea.Cmd {
-	return nil
+	return tea.Batch(
+		m.settingsModel.Init(),
+		m.aboutModel.Init(),
+		m.workspaceModel.Init(),
+		m.stream.Listen(),
+		// Pages start hidden, the first one is shown right away
+		func() tea.Msg { return global.PageVisibleMsg(true) },
+	)
 }
 
 func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	//	var cmd tea.Cmd
-	var cmds []tea.Cmd
-
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 
+		// Pages only get the space below the nav bar and inside the padding
+		width, height := m.pageSize()
+		return m.updatePage(tea.WindowSizeMsg{Width: width, Height: height})
+
 	// Modal stuff
 	case global.KillModalMsg:
 		m.modalModel.Close()
 		return m, nil
 	case global.SpawnModalMsg:
+		onCancel := func() tea.Cmd {
+			if msg.OnCancel != nil {
+				return func() tea.Msg { return msg.OnCancel() }
+			}
+			return nil
+		}
+		if msg.Prompt {
+			cmd := m.modalModel.OpenPrompt(
+				msg.Title, msg.Description, msg.Value,
+				func(value string) tea.Cmd {
+					if msg.OnSubmit != nil {
+						return func() tea.Msg { return msg.OnSubmit(value) }
+					}
+					return nil
+				},
+				onCancel,
+			)
+			return m, cmd
+		}
 		m.modalModel.Open(
 			msg.Title, msg.Description,
 			func() tea.Cmd {
@@ -78,19 +129,73 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return nil
 			},
-			func() tea.Cmd {
-				if msg.OnCancel != nil {
-					return func() tea.Msg { return msg.OnCancel() }
-				}
-				return nil
-			},
+			onCancel,
 		)
 		return m, nil
 
 	case global.InputFocusChangedMsg:
-		// Update the global input focus state
+		// Update the global input focus state, containers like the
+		// workspace split pane need it too
 		m.inputInFocus = bool(msg)
-		return m, nil
+		return m.updatePage(msg)
+
+	case global.SettingsChangedMsg:
+		// Another API streams other items, the old stream is dropped along
+		// with its pending messages
+		var listen tea.Cmd
+		if !msg.Settings.SameBackend(m.store.Settings()) {
+			m.stream.Close()
+			m.stream = actions.NewStream(msg.Settings)
+			m.streamState = actions.StreamOff
+			listen = m.stream.Listen()
+		}
+		m, cmd := m.broadcast(msg)
+		return m, tea.Batch(cmd, listen)
+
+	case actions.StreamEventMsg, actions.StreamStatusMsg:
+		if !m.stream.From(msg) {
+			return m, nil
+		}
+		if status, ok := msg.(actions.StreamStatusMsg); ok {
+			m.streamState = status.State
+		}
+		// Every page gets live changes, then the stream is asked for the
+		// next one
+		m, cmd := m.broadcast(msg)
+		return m, tea.Batch(cmd, m.stream.Listen())
+
+	case actions.DataLoadedMsg, actions.DataErrorMsg, spinner.TickMsg, actions.MutationDoneMsg,
+		actions.MutationFailedMsg, actions.ImportDoneMsg, components.NotificationExpiredMsg, components.PaneRatioSavedMsg,
+		pages.ItemSelectedMsg:
+		// Saved settings, the data they fetch and loading spinners concern
+		// every page, not just the visible one. The item panel follows Home
+		// from the Home page too
+		return m.broadcast(msg)
+
+	case components.SchedulerTickMsg:
+		// Pages don't refresh behind a modal or while something is typed,
+		// and hidden pages skip it themselves
+		msg.Paused = m.modalModel.IsOpen() || m.inputInFocus
+		return m.broadcast(msg)
+
+	case tea.MouseMsg:
+		if m.modalModel.IsOpen() {
+			cmd := m.modalModel.HandleMouse(msg)
+			return m, cmd
+		}
+
+		if m.showHelp {
+			return m, nil
+		}
+
+		// Nav entries switch pages, unless an input is being edited
+		if !m.inputInFocus {
+			for _, page := range navPages {
+				if components.Clicked(navZoneID(page), msg) {
+					return m.switchPage(page)
+				}
+			}
+		}
 
 	case tea.KeyMsg:
 		if m.modalModel.IsOpen() {
@@ -102,21 +207,7 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// If input is in focus, all keypresses should go to the page
 		if m.inputInFocus {
 			// Updates to focused inputs should be handled by the page
-			switch m.currentPage {
-			case "home":
-				homeModel, homeCmd := m.homeModel.Update(msg)
-				m.homeModel = homeModel.(pages.HomeModel)
-				return m, homeCmd
-			case "settings":
-				settingsModel, settingsCmd := m.settingsModel.Update(msg)
-				m.settingsModel = settingsModel.(pages.SettingsModel)
-				return m, settingsCmd
-			case "about":
-				aboutModel, aboutCmd := m.aboutModel.Update(msg)
-				m.aboutModel = aboutModel.(pages.AboutModel)
-				return m, aboutCmd
-			}
-			return m, nil
+			return m.updatePage(msg)
 		}
 
 		// Toggle help menu when no input is focused
@@ -139,143 +230,395 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keyMap.Quit):
 			return m, tea.Quit
 		case key.Matches(msg, m.keyMap.Home):
-			m.currentPage = "home"
-			return m, func() tea.Msg {
-				return tea.WindowSizeMsg{Width: m.width, Height: m.height}
-			}
+			return m.switchPage("home")
 		case key.Matches(msg, m.keyMap.Settings):
-			m.currentPage = "settings"
-			return m, func() tea.Msg {
-				return tea.WindowSizeMsg{Width: m.width, Height: m.height}
-			}
+			return m.switchPage("settings")
 		case key.Matches(msg, m.keyMap.About):
-			m.currentPage = "about"
-			return m, func() tea.Msg {
-				return tea.WindowSizeMsg{Width: m.width, Height: m.height}
-			}
+			return m.switchPage("about")
+		case key.Matches(msg, m.keyMap.Workspace):
+			return m.switchPage("workspace")
+		case key.Matches(msg, m.keyMap.Search) && m.searchable():
+			return m.updatePage(global.SearchMsg{})
 		}
 	}
 
 	// Update the current page model
+	return m.updatePage(msg)
+}
+
+// updatePage forwards msg to the model of the current page
+func (m appModel) updatePage(msg tea.Msg) (appModel, tea.Cmd) {
+	var cmd tea.Cmd
 	switch m.currentPage {
 	case "home":
-		homeModel, homeCmd := m.homeModel.Update(msg)
-		m.homeModel = homeModel.(pages.HomeModel)
-		if homeCmd != nil {
-			cmds = append(cmds, homeCmd)
-		}
+		m.workspaceModel, cmd = m.workspaceModel.UpdatePane(homePane, msg)
 	case "settings":
-		settingsModel, settingsCmd := m.settingsModel.Update(msg)
+		var settingsModel tea.Model
+		settingsModel, cmd = m.settingsModel.Update(msg)
 		m.settingsModel = settingsModel.(pages.SettingsModel)
-		if settingsCmd != nil {
-			cmds = append(cmds, settingsCmd)
-		}
 	case "about":
-		aboutModel, aboutCmd := m.aboutModel.Update(msg)
+		var aboutModel tea.Model
+		aboutModel, cmd = m.aboutModel.Update(msg)
 		m.aboutModel = aboutModel.(pages.AboutModel)
-		if aboutCmd != nil {
-			cmds = append(cmds, aboutCmd)
-		}
+	case "workspace":
+		var workspaceModel tea.Model
+		workspaceModel, cmd = m.workspaceModel.Update(msg)
+		m.workspaceModel = workspaceModel.(components.SplitPaneModel)
 	}
+	return m, cmd
+}
 
+// searchable reports whether the current page can search now. Elsewhere
+// the search key goes to the page like any other key
+func (m appModel) searchable() bool {
+	switch m.currentPage {
+	case "home":
+		return m.workspaceModel.Pane(homePane).(global.Searcher).Searchable()
+	case "about":
+		return m.aboutModel.Searchable()
+	case "workspace":
+		return m.workspaceModel.Searchable()
+	}
+	return false
+}
+
+// broadcast forwards msg to the models of all pages
+func (m appModel) broadcast(msg tea.Msg) (appModel, tea.Cmd) {
+	var cmds []tea.Cmd
+	current := m.currentPage
+	for _, page := range navPages {
+		// The workspace passes msg on to Home, its list pane
+		if page == "home" {
+			continue
+		}
+		var cmd tea.Cmd
+		m.currentPage = page
+		m, cmd = m.updatePage(msg)
+		cmds = append(cmds, cmd)
+	}
+	m.currentPage = current
 	return m, tea.Batch(cmds...)
 }
 
-func (m appModel) View() string {
-	// Navigation header with keybinding info
-	navText := fmt.Sprintf(
-		"%s: Home • %s: Settings • %s: About • %s: Quit • %s: Help",
-		m.keyMap.Home.Help().Key,
-		m.keyMap.Settings.Help().Key,
-		m.keyMap.About.Help().Key,
-		m.keyMap.Quit.Help().Key,
-		m.keyMap.Help.Help().Key,
+// Snapshot sums up the app for the debug recorder
+func (m appModel) Snapshot() debug.Snapshot {
+	return debug.Snapshot{
+		Page:         m.currentPage,
+		InputInFocus: m.inputInFocus,
+		ModalOpen:    m.modalModel.IsOpen(),
+		HelpOpen:     m.showHelp,
+		View:         m.View(),
+	}
+}
+
+// Page returns the name of the current page, as in navPages
+func (m appModel) Page() string {
+	return m.currentPage
+}
+
+// switchPage makes page current and resends the window size so it can lay out.
+// The page being left is blurred, so it can restore its focus when shown again
+func (m appModel) switchPage(page string) (tea.Model, tea.Cmd) {
+	m, blurCmd := m.updatePage(global.PageFocusChangedMsg(false))
+	m, hideCmd := m.updatePage(global.PageVisibleMsg(false))
+	m.currentPage = page
+	m, showCmd := m.updatePage(global.PageVisibleMsg(true))
+	m, focusCmd := m.updatePage(global.PageFocusChangedMsg(true))
+
+	return m, tea.Batch(
+		blurCmd,
+		hideCmd,
+		showCmd,
+		focusCmd,
+		func() tea.Msg {
+			return tea.WindowSizeMsg{Width: m.width, Height: m.height}
+		},
 	)
+}
 
-	nav := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FFFDF5")).
-		Background(lipgloss.Color("#2F4858")).
-		Padding(0, 1).
-		Width(m.width - 2).
-		Render(navText)
+// homePane is the pane of the workspace the Home page shows
+const homePane = 0
 
-	// Show help screen if toggled
-	if m.showHelp {
-		helpContent := "KEYBOARD SHORTCUTS\n\n"
-		helpContent += fmt.Sprintf("%-15s %s\n", m.keyMap.Home.Help().Key, "Go to Home page")
-		helpContent += fmt.Sprintf("%-15s %s\n", m.keyMap.Settings.Help().Key, "Go to Settings page")
-		helpContent += fmt.Sprintf("%-15s %s\n", m.keyMap.About.Help().Key, "Go to About page")
-		helpContent += fmt.Sprintf("%-15s %s\n", m.keyMap.Up.Help().Key, "Move up")
-		helpContent += fmt.Sprintf("%-15s %s\n", m.keyMap.Down.Help().Key, "Move down")
-		helpContent += fmt.Sprintf("%-15s %s\n", m.keyMap.Enter.Help().Key, "Select/Confirm")
-		helpContent += fmt.Sprintf("%-15s %s\n", m.keyMap.Back.Help().Key, "Go back")
-		helpContent += fmt.Sprintf("%-15s %s\n", m.keyMap.Help.Help().Key, "Show/hide help")
-		helpContent += fmt.Sprintf("%-15s %s\n", m.keyMap.Quit.Help().Key, "Quit application")
-		helpContent += fmt.Sprintf("%-15s", "")
-		helpContent += fmt.Sprintf("%-15s", "check github to learn about custom keymaps")
-
-		helpBox := lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
-			Padding(1).
-			Width(m.width - 4).
-			Height(m.height).
-			Render(helpContent)
-
-		return lipgloss.JoinVertical(lipgloss.Left, nav, helpBox)
-	}
+// navPages lists the pages reachable from the nav bar, in display order
+var navPages = []string{"home", "settings", "about", "workspace"}
 
-	// Content based on current page
-	var content string
-	switch m.currentPage {
-	case "home":
-		content = m.homeModel.View()
-	case "settings":
-		content = m.settingsModel.View()
-	case "about":
-		content = m.aboutModel.View()
-	}
+func navZoneID(page string) string {
+	return "nav-" + page
+}
 
-	styled := lipgloss.NewStyle().Padding(1).Render(content)
+// pagePadding is the space kept around page content on every side
+const pagePadding = 1
 
-	// Create the full UI by joining the navigation and content vertically
-	fullView := lipgloss.JoinVertical(lipgloss.Left, nav, styled)
+// appLayout puts the nav bar above the page area
+func appLayout(nav string) layout.Flex {
+	return layout.Column(
+		layout.Fixed("nav", lipgloss.Height(nav)),
+		layout.Flexible("page", 1),
+	)
+}
+
+// pageSize returns the size pages get to render into, inside the padding
+func (m appModel) pageSize() (int, int) {
+	page := appLayout(m.navView()).Layout(m.width, m.height)["page"]
+	return page.Width - 2*pagePadding, page.Height - 2*pagePadding
+}
+
+func (m appModel) View() string {
+	// Below the minimum size nothing renders properly, so ask for more room
+	if layout.TooSmall(m.width, m.height) {
+		return zone.Scan(m.tooSmallView())
+	}
 
 	// If modal is open, overlay it on top of the existing content
 	if m.modalModel.IsOpen() {
 		// Get the modal content with proper dimensions
 		modalView := m.modalModel.View(m.width, m.height)
 
-		// Create a semi-transparent overlay
-		overlay := lipgloss.NewStyle().
-			Width(m.width).
-			Height(m.height).
-			Background(lipgloss.Color("#000000")).
-			Foreground(lipgloss.Color("#FFFFFF")).
-			AlignHorizontal(lipgloss.Center).
-			AlignVertical(lipgloss.Center).
-			Render("")
-
-		// Center the modal in the viewport
-		centeredModal := lipgloss.Place(
+		// Center the modal in the viewport. It is exactly one screen tall, so
+		// it fully covers the page underneath
+		return zone.Scan(lipgloss.Place(
 			m.width,
 			m.height,
 			lipgloss.Center,
 			lipgloss.Center,
 			modalView,
+		))
+	}
+
+	nav := m.navView()
+	pageWidth, pageHeight := m.pageSize()
+
+	var page string
+	if m.showHelp {
+		// Show help screen if toggled
+		page = m.helpView(pageWidth+2*pagePadding, pageHeight+2*pagePadding)
+	} else {
+		// Content based on current page
+		var content string
+		switch m.currentPage {
+		case "home":
+			content = m.workspaceModel.Pane(homePane).View()
+		case "settings":
+			content = m.settingsModel.View()
+		case "about":
+			content = m.aboutModel.View()
+		case "workspace":
+			content = m.workspaceModel.View()
+		}
+		page = lipgloss.NewStyle().Padding(pagePadding).Render(content)
+	}
+
+	// Create the full UI by joining the navigation and content vertically
+	return zone.Scan(appLayout(nav).Render(m.width, m.height, map[string]string{
+		"nav":  nav,
+		"page": page,
+	}))
+}
+
+// navView renders the navigation header with keybinding info. Compact
+// terminals only get the keys, with the current page underlined
+func (m appModel) navView() string {
+	compact := layout.Compact(m.width)
+	labels := map[string]string{
+		"home":      "Home",
+		"settings":  "Settings",
+		"about":     "About",
+		"workspace": "Workspace",
+	}
+	bindings := map[string]key.Binding{
+		"home":      m.keyMap.Home,
+		"settings":  m.keyMap.Settings,
+		"about":     m.keyMap.About,
+		"workspace": m.keyMap.Workspace,
+	}
+
+	var entries []string
+	for _, page := range navPages {
+		text := bindings[page].Help().Key + ": " + labels[page]
+		if compact {
+			text = bindings[page].Help().Key
+		}
+		if page == m.currentPage {
+			text = lipgloss.NewStyle().Bold(true).Underline(true).Render(text)
+		}
+		entries = append(entries, zone.Mark(navZoneID(page), text))
+	}
+
+	navText := strings.Join(entries, " • ") + fmt.Sprintf(
+		" • %s: Quit • %s: Help",
+		m.keyMap.Quit.Help().Key,
+		m.keyMap.Help.Help().Key,
+	)
+	if compact {
+		navText = strings.Join(entries, " ") + fmt.Sprintf(
+			" │ %s %s",
+			m.keyMap.Quit.Help().Key,
+			m.keyMap.Help.Help().Key,
 		)
+	}
 
-		// Use Z-indexing (string concatenation) to layer views
-		return fullView + "\n" + overlay + "\n" + centeredModal
+	if m.stream != nil {
+		// The state is left out when it doesn't fit on the line
+		status := m.streamView(true)
+		if lipgloss.Width(navText+" • "+status) > m.width-4 {
+			status = m.streamView(false)
+		}
+		navText += " • " + status
+	}
 
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFFDF5")).
+		Background(lipgloss.Color("#2F4858")).
+		Padding(0, 1).
+		Width(max(m.width-2, 0)).
+		Render(navText)
+}
+
+// streamView shows whether live updates come in, as a colored dot and,
+// with label set, the state of the connection
+func (m appModel) streamView(label bool) string {
+	color := "#F44336"
+	switch m.streamState {
+	case actions.StreamLive:
+		color = "#25A065"
+	case actions.StreamConnecting:
+		color = "#FFB86C"
 	}
-	return fullView
+	dot := lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Background(lipgloss.Color("#2F4858")).Render("●")
+	if !label {
+		return dot
+	}
+	return dot + " " + m.streamState.String()
+}
+
+// tooSmallView asks the user to enlarge the terminal
+func (m appModel) tooSmallView() string {
+	message := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF6700")).
+		Bold(true).
+		Render(fmt.Sprintf(
+			"Please enlarge terminal\n(need %dx%d, have %dx%d)",
+			layout.MinWidth, layout.MinHeight, m.width, m.height,
+		))
+
+	return lipgloss.Place(
+		max(m.width, 0),
+		max(m.height, 0),
+		lipgloss.Center,
+		lipgloss.Center,
+		lipgloss.NewStyle().Align(lipgloss.Center).Render(message),
+	)
+}
+
+// helpView renders the keyboard shortcut overview into width x height. App
+// and Home shortcuts go side by side when they fit
+func (m appModel) helpView(width, height int) string {
+	app := helpColumn("APP",
+		helpLine("Go to Home page", m.keyMap.Home),
+		helpLine("Go to Settings page", m.keyMap.Settings),
+		helpLine("Go to About page", m.keyMap.About),
+		helpLine("Go to Workspace page", m.keyMap.Workspace),
+		helpLine("Move up", m.keyMap.Up),
+		helpLine("Move down", m.keyMap.Down),
+		helpLine("Select/Confirm", m.keyMap.Enter),
+		helpLine("Go back", m.keyMap.Back),
+		helpLine("Search the page", m.keyMap.Search),
+		helpLine("Paste into input", m.keyMap.Paste),
+		helpLine("Switch workspace pane", m.keyMap.PaneFocus),
+		helpLine("Resize workspace panes", m.keyMap.PaneGrow, m.keyMap.PaneShrink),
+		helpLine("Maximize workspace pane", m.keyMap.PaneMaximize),
+		helpLine("Collapse other pane", m.keyMap.PaneCollapse),
+		helpLine("Show/hide help", m.keyMap.Help),
+		helpLine("Debug overlay", m.keyMap.Debug),
+		helpLine("Quit application", m.keyMap.Quit),
+	)
+	home := helpColumn("HOME",
+		helpLine("Open item", m.keyMap.Enter),
+		helpLine("Reload data", m.keyMap.Refresh),
+		helpLine("Add/edit item", m.keyMap.Add, m.keyMap.Edit),
+		helpLine("Delete/duplicate item", m.keyMap.Delete, m.keyMap.Duplicate),
+		helpLine("Undo/redo last change", m.keyMap.Undo, m.keyMap.Redo),
+		helpLine("Save/forget filter", m.keyMap.SaveQuery),
+		helpLine("Change sort order", m.keyMap.Sort),
+		helpLine("Select/unselect item", m.keyMap.Select),
+		helpLine("Change status", m.keyMap.Status),
+		helpLine("Export/import items", m.keyMap.Export, m.keyMap.Import),
+		helpLine("Copy title, description or JSON", m.keyMap.Copy),
+	)
+
+	// Padding and border take two cells on every side
+	columns := lipgloss.JoinHorizontal(lipgloss.Top, app, "    ", home)
+	if lipgloss.Width(columns) > width-4 {
+		columns = lipgloss.JoinVertical(lipgloss.Left, app, "", home)
+	}
+	helpContent := lipgloss.JoinVertical(lipgloss.Left,
+		"KEYBOARD SHORTCUTS",
+		"",
+		columns,
+		"",
+		"check github to learn about custom keymaps",
+	)
+
+	// The border takes one cell on every side
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#874BFD")).
+		Padding(1).
+		Width(width - 2).
+		Height(height - 2).
+		Render(helpContent)
+}
+
+// helpColumn stacks help lines under a heading
+func helpColumn(heading string, lines ...string) string {
+	heading = lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")).Render(heading)
+	return lipgloss.JoinVertical(lipgloss.Left, append([]string{heading}, lines...)...)
+}
+
+// helpLine describes what one or more bindings do
+func helpLine(description string, bindings ...key.Binding) string {
+	keys := make([]string, len(bindings))
+	for i, binding := range bindings {
+		keys[i] = binding.Help().Key
+	}
+	return fmt.Sprintf("%-10s %s", strings.Join(keys, "/"), description)
 }
 
 func main() {
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	// A command runs instead of the app
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if _, err := runApp(nil); err != nil {
 		fmt.Printf("Error running program: %v", err)
 		os.Exit(1)
 	}
 }
+
+// runApp runs the app until it quits and returns what the program ended
+// with. wrap, if set, wraps the app in another model like the debug recorder
+func runApp(wrap func(appModel) tea.Model) (tea.Model, error) {
+	// Zones let mouse clicks be matched against rendered components
+	zone.NewGlobal()
+	defer zone.Close()
+
+	// Ask the terminal for its background while nothing else reads its
+	// input. Markdown picks its colors from the cached answer
+	lipgloss.HasDarkBackground()
+
+	model := initialModel()
+	defer model.store.Close()
+
+	var program tea.Model = model
+	if wrap != nil {
+		program = wrap(model)
+	}
+	// Copying through the terminal writes to the same output
+	p := tea.NewProgram(program, tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithOutput(actions.Stdout()))
+	return p.Run()
+}
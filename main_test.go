@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bubbletea-app/app/actions"
+	"bubbletea-app/app/apptest"
+	"bubbletea-app/app/config"
+	"bubbletea-app/app/debug"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	// Dates show in local time
+	time.Local = time.UTC
+	os.Exit(m.Run())
+}
+
+// newApp starts the app with a config dir of its own and a file backend
+// holding a few items
+func newApp(t *testing.T, width, height int) *apptest.Harness {
+	t.Helper()
+	return apptest.New(t, setupApp(t), width, height).Mask(`\d\d:\d\d:\d\d`, "12:00:00")
+}
+
+// setupApp creates the app newApp starts
+func setupApp(t *testing.T) appModel {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", home)
+	t.Chdir(home)
+
+	items := []actions.DataItem{
+		{ID: "1", Title: "Write the report", Status: "doing", Created: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)},
+		{ID: "2", Title: "Book the venue", Status: "todo", Created: time.Date(2025, 1, 7, 9, 0, 0, 0, time.UTC)},
+	}
+	settings := config.Settings{Backend: config.BackendFile, DataPath: "items.json"}
+	if err := actions.ExportItems(settings.DataPath, items); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SaveSettings(settings); err != nil {
+		t.Fatal(err)
+	}
+
+	model := initialModel()
+	t.Cleanup(func() { model.store.Close() })
+	return model
+}
+
+func TestApp(t *testing.T) {
+	h := newApp(t, 100, 30)
+	h.Golden("app_home")
+	h.Press("2").Golden("app_settings")
+	h.Press("3").Golden("app_about")
+	h.Press("4").Golden("app_workspace")
+	h.Press("?").Golden("app_help")
+	h.Press("?", "q")
+	if !h.Quitting() {
+		t.Error("q didn't quit")
+	}
+}
+
+// TestAppWorkspace checks the item panel follows the cursor of the list,
+// which is the same one the Home page shows
+func TestAppWorkspace(t *testing.T) {
+	h := newApp(t, 100, 30)
+	h.Press("j", "4").Golden("app_workspace_followed")
+	h.Press("k").Golden("app_workspace_moved")
+	h.Press("1")
+	if view := h.View(); !strings.Contains(view, "│ Write the report") {
+		t.Fatalf("Home didn't keep the cursor of the workspace:\n%s", view)
+	}
+}
+
+func TestAppPaneRatioNotSaved(t *testing.T) {
+	h := newApp(t, 100, 30)
+	// A folder where the file goes can't be written
+	if err := os.MkdirAll(config.PanesFileName(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	h.Press("4", "+")
+	if view := h.View(); !strings.Contains(view, "Could not save the pane sizes") {
+		t.Errorf("the failed save isn't shown:\n%s", view)
+	}
+}
+
+// TestAppSearch checks the search key goes to the pages that can search
+// their content: the Home list, on its own or in the workspace, and the
+// About text. It is an ordinary key on Settings
+func TestAppSearch(t *testing.T) {
+	h := newApp(t, 100, 30)
+	tests := []struct {
+		keys       []string
+		searchable bool
+	}{
+		{[]string{"1"}, true},
+		{[]string{"2"}, false},
+		{[]string{"3"}, true},
+		{[]string{"4"}, true},
+		{[]string{"ctrl+w"}, false},
+		{[]string{"ctrl+w"}, true},
+	}
+	for _, tt := range tests {
+		h.Press(tt.keys...)
+		m := h.Model().(appModel)
+		if got := m.searchable(); got != tt.searchable {
+			t.Errorf("after %v on %s: searchable = %t, want %t", tt.keys, m.Page(), got, tt.searchable)
+		}
+	}
+
+	h.Press("2", "/", "1")
+	if m := h.Model().(appModel); m.Page() != "home" || m.Snapshot().InputInFocus {
+		t.Errorf("/ on Settings started a search that caught the next key")
+	}
+	h.Press("/")
+	if !h.Model().(appModel).Snapshot().InputInFocus {
+		t.Error("/ on Home didn't start a search")
+	}
+	h.Press("esc", "3", "/")
+	if !h.Model().(appModel).Snapshot().InputInFocus {
+		t.Error("/ on About didn't start a search")
+	}
+	h.Type("key").Press("enter").Golden("app_about_find")
+	h.Press("n").Golden("app_about_find_next")
+	h.Press("esc")
+	if strings.Contains(h.View(), "Find:") {
+		t.Errorf("esc didn't clear the find bar:\n%s", h.View())
+	}
+}
+
+func TestAppModal(t *testing.T) {
+	h := newApp(t, 100, 30)
+	h.Press("d").Golden("app_delete_modal")
+	h.Press("enter").Golden("app_deleted")
+}
+
+func TestAppTooSmall(t *testing.T) {
+	newApp(t, 30, 10).Golden("app_too_small")
+}
+
+// TestTape plays the demo recorded for the README, with its assertions
+func TestTape(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", home)
+
+	tape, err := apptest.ReadTape("vhs.tape")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(home)
+
+	model := initialModel()
+	t.Cleanup(func() { model.store.Close() })
+	apptest.New(t, model, 100, 30).Play(tape)
+}
+
+// TestDebugReplay records a session with the debug recorder, steps back
+// through it and replays the exported recording on a new app
+func TestDebugReplay(t *testing.T) {
+	model := setupApp(t)
+	h := apptest.New(t, debug.New(model, model.keyMap, "recording.json"), 100, 30).
+		Mask(`\d\d:\d\d:\d\d(\.\d\d\d)?`, "12:00:00").
+		Mask(`\+\d+\.\d+s`, "+0.000s")
+
+	// Editing a Settings field and searching Home move the input focus
+	h.Press("2", "down", "enter").Type("8080").Press("esc", "1", "/").Type("report").Press("esc", "d", "esc")
+
+	h.Press("ctrl+t").Golden("debug_overlay")
+	h.Press("left", "left", "left").Golden("debug_overlay_back")
+	h.Press("x")
+	if view := h.View(); !strings.Contains(view, "to recording.json") {
+		t.Fatalf("no export notice:\n%s", view)
+	}
+	h.Press("esc")
+
+	recording, err := debug.LoadRecording("recording.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recording.Inputs()) == 0 {
+		t.Fatal("no inputs were recorded")
+	}
+	newApp(t, 100, 30).Replay(recording).Golden("debug_replayed")
+}
+
+func TestExportCommand(t *testing.T) {
+	setupApp(t)
+	if err := runCommand([]string{"export", "-o", "out/items.csv"}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile("out/items.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "id,title,description,status,created\n1,Write the report,,doing,"; !strings.HasPrefix(string(data), want) {
+		t.Errorf("exported\n%s\nwant it to start with\n%s", data, want)
+	}
+
+	for _, args := range [][]string{
+		{"-format", "xml"},
+		{"-format", "csv", "-o", "items.md"},
+		{"-o", "items"},
+		{"-to", "items.csv"},
+	} {
+		if err := runCommand(append([]string{"export"}, args...)); err == nil {
+			t.Errorf("export %s didn't fail", strings.Join(args, " "))
+		}
+	}
+}